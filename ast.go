@@ -1,11 +1,20 @@
 package main
 
-// PROGRAM -> METH-LIST | e
+// PROGRAM -> (STRUCT | GLOBAL | METH)*
 type AST struct {
-	Methods []Method // lista methodwn
+	Structs []StructDeclaration // orismoi struct
+	Globals []Declaration       // global metavlhtes kai stathere
+	Methods []Method            // lista methodwn
 }
 
-// METH -> TYPE id '(' PARAMS ')' BODY
+// STRUCT -> struct id '{' DECLS '}' [';']
+type StructDeclaration struct {
+	Name    string
+	Members []Declaration // ta melh me th seira pou pianoun lekseis
+	Line    int
+}
+
+// METH -> TYPE id '(' PARAMS ')' BODY | void id '(' PARAMS ')' BODY
 type Method struct {
 	ReturnType string
 	Name       string
@@ -14,22 +23,27 @@ type Method struct {
 	Line       int // errors
 }
 
-// FORMALS -> TYPE id
+// FORMALS -> TYPE id | ref TYPE id
 type Parameter struct {
 	Type string
 	Name string
-	Line int // errors
+	Ref  bool // pernaei h dieythinsh tou orismatos
+	Line int  // errors
 }
 
 // BODY -> '{' DECLS STMTS '}'
 type Block struct {
 	Declarations []Declaration // dhlwseis metablhtwn
 	Statements   []Statement   // entoles
+	Scope        *SymbolTable  // scope tou block (apo th semantic analysh, nil gia to body)
 }
 
-// DECL -> TYPE id VARS ';' | TYPE id '=' EXPR VARS ';'
+// DECL -> TYPE VAR VARS ';'
+// VAR -> id | id '=' EXPR | id '[' EXPR ']' | id '[' EXPR ']' '=' string
+// GLOBAL -> DECL | const DECL
 type Declaration struct {
 	Type      string
+	Const     bool       // const (mono global)
 	Variables []Variable //lista metablhtwn
 	Line      int        // grammh declare
 }
@@ -37,6 +51,8 @@ type Declaration struct {
 // metablhth
 type Variable struct {
 	Name         string
+	SizeExpr     Expression // statherh ekfrash tou megethous an einai pinakas (nil alliws)
+	Size         int        // plhthos stoixeiwn (apo th semantic analysh, 0 an den einai pinakas)
 	InitialValue Expression // arxikh timh (nil an den yparxei)
 }
 
@@ -48,13 +64,22 @@ type Statement interface {
 // anathesh : ASSIGN -> LOCATION '=' EXPR
 type Assignment struct {
 	Variable   string     // onoma metablhths
+	Index      Expression // deikths an einai stoixeio pinaka (nil alliws)
+	Member     string     // melos an einai struct ("" alliws)
+	Field      *Field     // pedio ths lekshs (nil gia olh th leksh)
 	Expression Expression // ekfrash
 	Line       int        // grammh
 }
 
+// field specification (L:R) tou MIX, ta bytes L..R ths lekshs (0 = proshmo)
+type Field struct {
+	Left  int
+	Right int
+}
+
 // return
 type ReturnStatement struct {
-	Expression Expression // express pou ginetai return
+	Expression Expression // express pou ginetai return (nil gia void)
 	Line       int
 }
 
@@ -73,9 +98,59 @@ type WhileStatement struct {
 	Line      int
 }
 
-// break
+// klhsh methodou ws entolh: CALL ';'
+type ExpressionStatement struct {
+	Expression Expression // h klhsh, h timh ths agnoeitai
+	Line       int
+}
+
+// for '(' SIMPLE ';' EXPR ';' SIMPLE ')' STMT
+type ForStatement struct {
+	Init      Statement  // arxikopoihsh (nil an leipei)
+	Condition Expression // synthiki (nil = panta true)
+	Step      Statement  // vhma (nil an leipei)
+	Body      Statement  // broxgos
+	Line      int
+}
+
+// do STMT while '(' EXPR ')' ';'
+type DoWhileStatement struct {
+	Body      Statement  // ektelitai toulaxiston mia fora
+	Condition Expression // synthiki
+	Line      int
+}
+
+// switch '(' EXPR ')' '{' CASE* '}'
+type SwitchStatement struct {
+	Expression Expression   // timh pou elegxetai
+	Cases      []SwitchCase // me th seira tou kwdika (gia to fallthrough)
+	Line       int
+}
+
+// CASE -> case EXPR ':' STMTS | default ':' STMTS
+type SwitchCase struct {
+	Value      Expression  // statherh ekfrash (nil gia to default)
+	Statements []Statement // entoles mexri to epomeno case
+	Line       int
+}
+
+// id ':' STMT, to STMT prepei na einai broxgos
+type LabeledStatement struct {
+	Label     string
+	Statement Statement
+	Line      int
+}
+
+// break | break id
 type BreakStatement struct {
-	Line int
+	Label string // etiketa broxgou ("" gia ton eswterotero)
+	Line  int
+}
+
+// continue | continue id
+type ContinueStatement struct {
+	Label string // etiketa broxgou ("" gia ton eswterotero)
+	Line  int
 }
 
 // block entolwn {}
@@ -84,6 +159,17 @@ type BlockStatement struct {
 	Line  int
 }
 
+// asm '{' grammes MIXAL '}', to %onoma ginetai h dieythinsh ths metablhths
+type AsmStatement struct {
+	Lines []AsmLine // oi mh kenes grammes tou block
+	Line  int
+}
+
+type AsmLine struct {
+	Text string // h grammh xwris ta kena sthn arxh kai sto telos
+	Line int    // grammh ston phgaio kwdika
+}
+
 // interface ekfrasewn
 type Expression interface {
 	expressionNode()
@@ -91,10 +177,11 @@ type Expression interface {
 
 // dyadikh ekfrash p.x. a + b , a == b ktlp
 type BinaryExpression struct {
-	Left     Expression
-	Operator string
-	Right    Expression
-	Line     int
+	Left        Expression
+	Operator    string
+	Right       Expression
+	OperandType string // typos twn telestwn meta to promotion (apo th semantic analysh)
+	Line        int
 }
 
 // monadikh ekfrash p.x. (-x)
@@ -110,16 +197,66 @@ type Identifier struct {
 	Line int
 }
 
+// stoixeio pinaka p.x. a[i]
+type IndexExpression struct {
+	Name  string
+	Index Expression
+	Line  int
+}
+
 type NumberLiteral struct {
 	Value string // p.x. "123", "4", "-5"
 	Line  int
 }
 
+// dekadikos p.x. "3.14", ginetai leksh float tou MIX
+type FloatLiteral struct {
+	Value string
+	Line  int
+}
+
 type BooleanLiteral struct {
 	Value bool // true h false
 	Line  int
 }
 
+// xarakthras p.x. 'A', h timh tou einai o kwdikos MIX
+type CharLiteral struct {
+	Value byte // xarakthras sto alfavhto tou MIX
+	Line  int
+}
+
+// keimeno p.x. "HELLO", pakettarismeno 5 xarakthres ana leksh
+type StringLiteral struct {
+	Value string
+	Line  int
+}
+
+// metatroph typou p.x. (float) x, thn prosthetei kai h semantic analysh
+// gia to promotion int -> float
+type CastExpression struct {
+	Type       string
+	Expression Expression
+	FromType   string // typos ths ekfrashs (apo th semantic analysh)
+	Line       int
+}
+
+// pedio lekshs p.x. x.(1:3), a[i].(4:5) h p.x.(0:2), h timh tou einai int
+type FieldExpression struct {
+	Name   string
+	Index  Expression // deikths an einai stoixeio pinaka (nil alliws)
+	Member string     // melos an einai struct ("" alliws)
+	Field  Field
+	Line   int
+}
+
+// melos struct p.x
+type MemberExpression struct {
+	Name   string
+	Member string
+	Line   int
+}
+
 // klhsh methodou
 type MethodCall struct {
 	Name      string
@@ -128,16 +265,30 @@ type MethodCall struct {
 }
 
 // interfaces
-func (a *Assignment) statementNode()      {}
-func (r *ReturnStatement) statementNode() {}
-func (i *IfStatement) statementNode()     {}
-func (w *WhileStatement) statementNode()  {}
-func (b *BreakStatement) statementNode()  {}
-func (b *BlockStatement) statementNode()  {}
+func (a *Assignment) statementNode()          {}
+func (r *ReturnStatement) statementNode()     {}
+func (i *IfStatement) statementNode()         {}
+func (w *WhileStatement) statementNode()      {}
+func (b *BreakStatement) statementNode()      {}
+func (c *ContinueStatement) statementNode()   {}
+func (f *ForStatement) statementNode()        {}
+func (d *DoWhileStatement) statementNode()    {}
+func (s *SwitchStatement) statementNode()     {}
+func (l *LabeledStatement) statementNode()    {}
+func (e *ExpressionStatement) statementNode() {}
+func (b *BlockStatement) statementNode()      {}
+func (a *AsmStatement) statementNode()        {}
 
 func (b *BinaryExpression) expressionNode() {}
 func (u *UnaryExpression) expressionNode()  {}
 func (i *Identifier) expressionNode()       {}
+func (i *IndexExpression) expressionNode()  {}
 func (n *NumberLiteral) expressionNode()    {}
+func (f *FloatLiteral) expressionNode()     {}
+func (c *CastExpression) expressionNode()   {}
+func (f *FieldExpression) expressionNode()  {}
+func (m *MemberExpression) expressionNode() {}
 func (b *BooleanLiteral) expressionNode()   {}
+func (c *CharLiteral) expressionNode()      {}
+func (s *StringLiteral) expressionNode()    {}
 func (m *MethodCall) expressionNode()       {}
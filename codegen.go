@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/val-makkas/mixal_compiler/mix"
 )
 
 const (
@@ -11,26 +14,399 @@ const (
 	VAR_START   = 2000 // storage metavlhtwn
 	TEMP_START  = 3000 // storage temp
 	STACK_START = 3500 // stack storage
+	STACK_END   = 4000 // telos mnhmhs
 )
 
+// to MOVE metaferei to poly F lekseis (ena byte)
+const MAX_MOVE = mix.ByteSize - 1
+
+// Runtime stack: to rI6 deixnei sth vash tou frame ths trexousas methodou.
+// Frame: [0] dieythinsh epistrofhs, [1..] parametroi kai metavlhtes
+// (Symbol.Offset+1), meta ta temps. O caller kanei INC6/DEC6 kata to
+// megethos tou diko tou frame (<LABEL>F EQU n) gyrw apo to JMP, afou
+// elegksei oti to frame tou callee xwraei mexri to STACK_END (ERRSTK).
+// To rI5 xrhsimopoieitai mono ston epilogo gia thn epistrofh.
+// To rI1 krataei FP + deikth gia ta stoixeia pinakwn (LDA base,1).
+// Oi routines twn builtins xrhsimopoioun ta rI1-rI4 mono mesa tous.
+
+// runtime errors: o kwdikas menei sto rX kai to programma stamataei (ta
+// labels, opws kai oi routines, den symptoun me tis methodous M1, M2, ...)
+var runtimeErrors = []struct {
+	Label string
+	Code  int
+}{
+	{"ERRSTK", 1}, // stack overflow
+	{"ERROVF", 2}, // to apotelesma den xwraei se mia leksh
+	{"ERRDIV", 3}, // diairesh me mhden
+	{"ERRIDX", 4}, // deikths pinaka ektos oriwn
+	{"ERRSHF", 5}, // arnhtikos arithmos bytes se metatopish
+}
+
+// ta labels enos broxgou me etiketa (outer: while ...)
+type loopTarget struct {
+	breakLabel    string
+	continueLabel string
+}
+
+// routines gia ta builtins kai tis prakseis long, paragontai mia fora sto telos
+// an xrhsimopoiountai. Kaloun me JMP kai epistrefoun me to STJ sto JMP * tou
+// telous, xalane ta rA, rX kai rI1-rI4 (kanena apo auta den einai zwntano
+// meta apo klhsh). Ta labels tous den symptoun me tis methodous tou xrhsth,
+// pou pairnoun M1, M2, ...
+var runtimeRoutines = []struct {
+	Label string
+	Code  []string
+}{
+	// PRINT: h timh tou rA se mia grammh, xwris ta mhdenika mprosta
+	{"PRINT", []string{
+		"PRINT   STJ   PRINTX",
+		"        ENT3  0",
+		"        JANN  *+2",
+		"        ENT3  1",
+		"        CHAR",
+		"        ENT2  9",
+		"PRINT1  J2Z   PRINT2",
+		"        CMPA  PRZERO(1:1)",
+		"        JNE   PRINT2",
+		"        SLAX  1",
+		"        DEC2  1",
+		"        JMP   PRINT1",
+		"PRINT2  STZ   PRBUF+2",
+		"        J3Z   PRINT3",
+		"        STX   PRBUF+2(1:1)",
+		"        SRAX  1",
+		"        STA   PRBUF",
+		"        ENTA  45",
+		"        STA   PRBUF(1:1)",
+		"        JMP   PRINT4",
+		"PRINT3  STA   PRBUF",
+		"PRINT4  STX   PRBUF+1",
+		fmt.Sprintf("        OUT   PRBUF(%d)", mix.LinePrinterUnit),
+		fmt.Sprintf("        JBUS  *(%d)", mix.LinePrinterUnit),
+		"PRINTX  JMP   *",
+		"PRZERO  CON   30(1:1)",
+		"PRBUF   ORIG  *+24",
+	}},
+	// PRSTR: h grammh twn 24 lekseon sth dieythinsh tou rI1
+	{"PRSTR", []string{
+		"PRSTR   STJ   PRSTRX",
+		fmt.Sprintf("        OUT   0,1(%d)", mix.LinePrinterUnit),
+		fmt.Sprintf("        JBUS  *(%d)", mix.LinePrinterUnit),
+		"PRSTRX  JMP   *",
+	}},
+	// READ: mia karta, o prwtos akeraios (kena, proshmo, pshfia) sto rA
+	{"READ", []string{
+		"READ    STJ   READX",
+		fmt.Sprintf("        IN    RDBUF(%d)", mix.CardReaderUnit),
+		fmt.Sprintf("        JBUS  *(%d)", mix.CardReaderUnit),
+		"        STZ   RDVAL",
+		"        STZ   RDSGN",
+		"        ENT2  0",
+		"        ENT3  0",
+		"        ENT4  0",
+		"READ1   CMP3  =16=",
+		"        JGE   READ9",
+		"        LDA   RDBUF,3",
+		"        SLA   0,4",
+		"        SRA   4",
+		"        INC4  1",
+		"        CMP4  =5=",
+		"        JL    READ2",
+		"        ENT4  0",
+		"        INC3  1",
+		"READ2   CMPA  =30=",
+		"        JL    READ5",
+		"        CMPA  =39=",
+		"        JG    READ5",
+		"        SUB   =30=",
+		"        STA   RDDIG",
+		"        LDA   RDVAL",
+		"        MUL   =10=",
+		"        JANZ  ERROVF",
+		"        SLAX  5",
+		"        ADD   RDDIG",
+		"        JOV   ERROVF",
+		"        STA   RDVAL",
+		"        ENT2  1",
+		"        JMP   READ1",
+		"READ5   J2P   READ9",
+		"        JAZ   READ1",
+		"        ENT2  1",
+		"        CMPA  =44=",
+		"        JE    READ1",
+		"        CMPA  =45=",
+		"        JNE   READ9",
+		"        STA   RDSGN",
+		"        JMP   READ1",
+		"READ9   LDA   RDVAL",
+		"        LDX   RDSGN",
+		"        JXZ   READX",
+		"        LDAN  RDVAL",
+		"READX   JMP   *",
+		"RDVAL   CON   0",
+		"RDSGN   CON   0",
+		"RDDIG   CON   0",
+		"RDBUF   ORIG  *+16",
+	}},
+	// LADD, LSUB: rAX = rAX +- (0,1 : 1,1), o long pou deixnei to rI1.
+	// To krathma tou xamhlou merous erxetai apo to overflow toggle kai sto
+	// telos ta dyo merh pairnoun to idio proshmo
+	{"LADD", []string{
+		"LSUB    STJ   LADDX",
+		"        STA   LAH",
+		"        LDAN  0,1",
+		"        STA   LANEG",
+		"        LDAN  1,1",
+		"        STA   LANEG+1",
+		"        ENT1  LANEG",
+		"        LDA   LAH",
+		"        JMP   LADD1",
+		"LADD    STJ   LADDX",
+		"LADD1   JNOV  *+1",
+		"        STA   LAH",
+		"        STX   LAL",
+		"        LDA   LAL",
+		"        ADD   1,1",
+		"        STA   LAL",
+		"        ENT2  0",
+		"        JNOV  LADD2",
+		"        ENT2  1",
+		"        JANN  LADD2",
+		"        ENT2  -1",
+		"LADD2   LDA   LAH",
+		"        ADD   0,1",
+		"        JOV   ERROVF",
+		"        INCA  0,2",
+		"        JOV   ERROVF",
+		"        STA   LAH",
+		"        JAP   LADD3",
+		"        JAZ   LADD5",
+		"        LDX   LAL",
+		"        JXNP  LADD5",
+		"        INCA  1",
+		"        STA   LAH",
+		"        LDA   LAL",
+		"        SUB   =1073741823=",
+		"        DECA  1",
+		"        STA   LAL",
+		"        JMP   LADD5",
+		"LADD3   LDX   LAL",
+		"        JXNN  LADD5",
+		"        DECA  1",
+		"        STA   LAH",
+		"        LDA   LAL",
+		"        ADD   =1073741823=",
+		"        INCA  1",
+		"        STA   LAL",
+		"LADD5   LDA   LAH",
+		"        JANZ  LADD6",
+		"        LDA   LAL(0:0)",
+		"LADD6   LDX   LAL",
+		"LADDX   JMP   *",
+		"LAH     CON   0",
+		"LAL     CON   0",
+		"LANEG   CON   0",
+		"        CON   0",
+	}},
+	// LMUL: rAX = rAX * (0,1 : 1,1). Ta megethh pollaplasiazontai me MUL,
+	// to apotelesma prepei na xwraei se dyo lekseis (alliws ERROVF)
+	{"LMUL", []string{
+		"LMUL    STJ   LMULX",
+		"        STA   LMH",
+		"        STX   LML",
+		"        JANZ  *+2",
+		"        LDA   LML",
+		"        STA   LMS(0:0)",
+		"        LDA   0,1",
+		"        JANZ  *+2",
+		"        LDA   1,1",
+		"        STA   LMT(0:0)",
+		"        LDA   LMS",
+		"        MUL   LMT",
+		"        STX   LMS",
+		"        LDA   LMH(1:5)",
+		"        JAZ   LMUL1",
+		"        LDA   0,1(1:5)",
+		"        JANZ  ERROVF",
+		"LMUL1   LDA   LMH(1:5)",
+		"        MUL   1,1(1:5)",
+		"        JANZ  ERROVF",
+		"        STX   LMC",
+		"        LDA   LML(1:5)",
+		"        MUL   0,1(1:5)",
+		"        JANZ  ERROVF",
+		"        STX   LMD",
+		"        LDA   LML(1:5)",
+		"        MUL   1,1(1:5)",
+		"        JNOV  *+1",
+		"        ADD   LMC",
+		"        JOV   ERROVF",
+		"        ADD   LMD",
+		"        JOV   ERROVF",
+		"        STA   LMH",
+		"        STX   LML",
+		"        LDA   LMS",
+		"        STA   LMH(0:0)",
+		"        STA   LML(0:0)",
+		"        LDA   LMH",
+		"        LDX   LML",
+		"LMULX   JMP   *",
+		"LMH     CON   0",
+		"LML     CON   0",
+		"LMS     CON   1",
+		"LMT     CON   1",
+		"LMC     CON   0",
+		"LMD     CON   0",
+	}},
+	// LDIV, LMOD: phliko h ypoloipo tou rAX me ton long sto rI1, opws sto
+	// int. Diairesh bit pros bit twn megethwn se 60 vhmata: to phliko
+	// mpainei sta deksia tou diaireteou kathws autos olisthainei aristera
+	{"LDIV", []string{
+		"LDIV    STJ   LDIVX",
+		"        ENT4  0",
+		"        JMP   LDIV0",
+		"LMOD    STJ   LDIVX",
+		"        ENT4  1",
+		"LDIV0   STA   LDNH",
+		"        STX   LDNL",
+		"        JANZ  *+2",
+		"        LDA   LDNL",
+		"        STA   LDSR(0:0)",
+		"        LDA   0,1",
+		"        JANZ  *+2",
+		"        LDA   1,1",
+		"        STA   LDSQ(0:0)",
+		"        LDA   LDSR",
+		"        MUL   LDSQ",
+		"        STX   LDSQ",
+		"        LDA   1,1(1:5)",
+		"        STA   LDDL",
+		"        LDA   0,1(1:5)",
+		"        STA   LDDH",
+		"        JANZ  *+3",
+		"        LDX   LDDL",
+		"        JXZ   ERRDIV",
+		"        LDA   LDNH(1:5)",
+		"        STA   LDNH",
+		"        LDA   LDNL(1:5)",
+		"        STA   LDNL",
+		"        STZ   LDRH",
+		"        STZ   LDRL",
+		"        ENT3  60",
+		"LDIV1   JNOV  *+1",
+		"        LDA   LDNL",
+		"        ADD   LDNL",
+		"        STA   LDNL",
+		"        ENT2  0",
+		"        JNOV  *+2",
+		"        ENT2  1",
+		"        LDA   LDNH",
+		"        ADD   LDNH",
+		"        INCA  0,2",
+		"        STA   LDNH",
+		"        ENT2  0",
+		"        JNOV  *+2",
+		"        ENT2  1",
+		"        LDA   LDRL",
+		"        ADD   LDRL",
+		"        INCA  0,2",
+		"        STA   LDRL",
+		"        ENT2  0",
+		"        JNOV  *+2",
+		"        ENT2  1",
+		"        LDA   LDRH",
+		"        ADD   LDRH",
+		"        INCA  0,2",
+		"        STA   LDRH",
+		"        JOV   LDIV2",
+		"        CMPA  LDDH",
+		"        JL    LDIV3",
+		"        JG    LDIV2",
+		"        LDA   LDRL",
+		"        CMPA  LDDL",
+		"        JL    LDIV3",
+		"LDIV2   LDA   LDRL",
+		"        SUB   LDDL",
+		"        ENT2  0",
+		"        JANN  *+4",
+		"        ADD   =1073741823=",
+		"        INCA  1",
+		"        ENT2  1",
+		"        STA   LDRL",
+		"        LDA   LDRH",
+		"        SUB   LDDH",
+		"        DECA  0,2",
+		"        JANN  *+3",
+		"        ADD   =1073741823=",
+		"        INCA  1",
+		"        STA   LDRH",
+		"        LDA   LDNL",
+		"        INCA  1",
+		"        STA   LDNL",
+		"LDIV3   DEC3  1",
+		"        J3P   LDIV1",
+		"        J4P   LDIV4",
+		"        LDA   LDSQ",
+		"        STA   LDNH(0:0)",
+		"        STA   LDNL(0:0)",
+		"        LDA   LDNH",
+		"        LDX   LDNL",
+		"        JMP   LDIVX",
+		"LDIV4   LDA   LDSR",
+		"        STA   LDRH(0:0)",
+		"        STA   LDRL(0:0)",
+		"        LDA   LDRH",
+		"        LDX   LDRL",
+		"LDIVX   JMP   *",
+		"LDNH    CON   0",
+		"LDNL    CON   0",
+		"LDRH    CON   0",
+		"LDRL    CON   0",
+		"LDDH    CON   0",
+		"LDDL    CON   0",
+		"LDSQ    CON   1",
+		"LDSR    CON   1",
+	}},
+}
+
 type CodeGenerator struct {
 	output         strings.Builder         // mixal code
 	labelCounter   int                     // counter gia ta labels
-	tempCounter    int                     // counter gia ta temp metavlhtes
-	addressMap     map[string]int          // Var onoma -> memory address
+	tempCounter    int                     // temps se xrhsh sto trexon frame
+	tempBase       int                     // prwto offset temp sto trexon frame
+	maxTemps       int                     // megisto plhthos temps ths methodou
+	addressMap     map[string]int          // Var onoma -> offset sto frame
 	currentAddress int                     // current memory address
 	breakLabels    []string                // stack gia ta break
+	continueLabels []string                // stack gia ta continue
+	pendingLabel   string                  // etiketa pou perimenei ton broxgo ths
+	loopTargets    map[string]loopTarget   // etiketa broxgou -> break/continue labels
 	methodLabels   map[string]string       // Method onoma -> mixal label
 	symbolTables   map[string]*SymbolTable // symbol tables gia kathe method
+	scope          *SymbolTable            // trexon scope (methodos h block)
+	globalTable    *SymbolTable            // global metavlhtes kai stathere
+	globalAddress  map[string]int          // global onoma -> memory address
+	usedErrors     map[string]bool         // runtime errors pou xreiazontai
+	dataBlocks     []dataBlock             // keimena gia tous topikous pinakes
+	usedRoutines   map[string]bool         // routines twn builtins pou xreiazontai
+}
+
+// dedomena meta ton kwdika (p.x. ta keimena twn pinakwn)
+type dataBlock struct {
+	label string
+	lines []string
 }
 
 func NewCodeGenerator() *CodeGenerator {
 	return &CodeGenerator{
 		addressMap:     make(map[string]int),
+		globalAddress:  make(map[string]int),
+		loopTargets:    make(map[string]loopTarget),
 		methodLabels:   make(map[string]string),
+		usedErrors:     make(map[string]bool),
+		usedRoutines:   make(map[string]bool),
 		currentAddress: VAR_START,
 		labelCounter:   1,
-		tempCounter:    1,
 	}
 }
 
@@ -38,15 +414,27 @@ func (c *CodeGenerator) Generate(ast *AST, symbolTables map[string]*SymbolTable)
 	c.output.Reset()
 	c.symbolTables = symbolTables
 
+	// oi pinakes twn methodwn exoun gonio ton global pinaka
+	for _, table := range symbolTables {
+		c.globalTable = table.Parent
+		break
+	}
+
 	// memory allocation
 	if err := c.allocateMemory(symbolTables); err != nil {
 		return "", fmt.Errorf("memory allocation error: %w", err)
 	}
 
+	// global metavlhtes
+	if err := c.generateGlobals(ast); err != nil {
+		return "", fmt.Errorf("memory allocation error: %w", err)
+	}
+
 	// method label gen
 	c.generateMethodLabels(ast)
 
 	// main generation
+	codeStart := c.output.Len()
 	if err := c.generateMainProgram(ast); err != nil {
 		return "", fmt.Errorf("main proccess generation error: %w", err)
 	}
@@ -58,45 +446,154 @@ func (c *CodeGenerator) Generate(ast *AST, symbolTables map[string]*SymbolTable)
 	// telos programmatos
 	c.generateFooter()
 
+	// o kwdikas den prepei na grapsei panw stis global metavlhtes h sto stack
+	if err := c.checkCodeSize(c.output.String()[codeStart:]); err != nil {
+		return "", fmt.Errorf("memory allocation error: %w", err)
+	}
+
 	return c.output.String(), nil
 }
 
 func (c *CodeGenerator) allocateMemory(symbolTables map[string]*SymbolTable) error {
-	// allocate mnhmh gia parametrous
+	// parametroi kai metavlhtes pairnoun thesh sto frame meta th dieythinsh epistrofhs
 	for methodName, table := range symbolTables {
-		paramCount := 0
-		for _, symbol := range table.Symbols {
-			if symbol.Kind == "parameter" {
-				fullName := c.makeParameterName(methodName, paramCount)
-				c.addressMap[fullName] = c.currentAddress
-				c.currentAddress++
-				paramCount++
-			}
+		c.allocateScope(methodName, table)
+	}
+	return nil
+}
+
+// oi metavlhtes tou scope kai twn eswterikwn blocks (to onoma tou scope ta ksexwrizei)
+func (c *CodeGenerator) allocateScope(methodName string, table *SymbolTable) {
+	for varName, symbol := range table.Symbols {
+		switch symbol.Kind {
+		case "parameter":
+			fullName := c.makeParameterName(methodName, symbol.Offset)
+			c.addressMap[fullName] = symbol.Offset + 1
+		case "variable":
+			// dhmiourgia monadikou onomatos
+			fullName := c.makeVariableName(table.Name, varName)
+			c.addressMap[fullName] = symbol.Offset + 1
 		}
 	}
 
-	// allocate mnhmh gia tis metavlites
-	for methodName, table := range symbolTables {
-		for varName, symbol := range table.Symbols {
-			if symbol.Kind == "variable" {
-				// dhmiourgia monadikou onomatos
-				fullName := c.makeVariableName(methodName, varName)
-				c.addressMap[fullName] = c.currentAddress
+	for _, child := range table.Children {
+		c.allocateScope(methodName, child)
+	}
+}
+
+// oi global metavlhtes pairnoun stathera theseis apo to VAR_START me
+// arxikh timh se CON, oi stathere den pianoun mnhmh (ginontai literals)
+func (c *CodeGenerator) generateGlobals(ast *AST) error {
+	if len(ast.Globals) == 0 {
+		return nil
+	}
+
+	c.output.WriteString(fmt.Sprintf("        ORIG  %d\n", VAR_START))
+	for _, decl := range ast.Globals {
+		if decl.Const {
+			continue
+		}
+
+		for _, variable := range decl.Variables {
+			symbol, exists := c.globalTable.Lookup(variable.Name)
+			if !exists {
+				return fmt.Errorf("global '%s' not found", variable.Name)
+			}
+			c.globalAddress[variable.Name] = c.currentAddress
+
+			if text, ok := variable.InitialValue.(*StringLiteral); ok && symbol.Size > 0 {
+				// to keimeno grafetai kateytheian stis lekseis tou pinaka
+				for _, line := range stringData(decl.Type, text.Value, symbol.Size) {
+					c.output.WriteString(fmt.Sprintf("        %s\n", line))
+				}
+				c.currentAddress += symbol.Size
+			} else if symbol.Size > 0 || symbol.Struct != nil {
+				// o pinakas kai to struct pairnoun synexomenes lekseis, arxika 0
+				c.currentAddress += symbol.Words()
+				c.output.WriteString(fmt.Sprintf("        ORIG  %d\n", c.currentAddress))
+			} else if symbol.Type == "long" {
+				high, low := longWords(symbol.Value)
+				c.output.WriteString(fmt.Sprintf("        CON   %d\n", high))
+				c.output.WriteString(fmt.Sprintf("        CON   %d\n", low))
+				c.currentAddress += 2
+			} else {
+				c.output.WriteString(fmt.Sprintf("        CON   %d\n", symbol.Value))
 				c.currentAddress++
 			}
 		}
 	}
+
+	if c.currentAddress > STACK_START {
+		return fmt.Errorf("global variables (%d words) do not fit below the stack", c.currentAddress-VAR_START)
+	}
 	return nil
 }
 
+// o kwdikas kai to literal pool tou prepei na teleiwnoun prin tis global
+// metavlhtes, h prin to stack an den yparxoun
+func (c *CodeGenerator) checkCodeSize(code string) error {
+	end := codeEnd(code)
+	if c.currentAddress > VAR_START && end > VAR_START {
+		return fmt.Errorf("program code (%d words) does not fit below the global variables", end-CODE_START)
+	}
+	if end > STACK_START {
+		return fmt.Errorf("program code (%d words) does not fit below the stack", end-CODE_START)
+	}
+	return nil
+}
+
+// h prwth eleytherh thesh meta ton kwdika MIXAL kai to literal pool tou END:
+// kathe entolh, CON h ALF pianei mia leksh, to ORIG *+n afhnei n lekseis
+// kai kathe diaforetiko =literal= pairnei mia leksh sto pool
+func codeEnd(code string) int {
+	location := CODE_START
+	literals := make(map[string]bool)
+	for _, line := range strings.Split(code, "\n") {
+		if strings.TrimSpace(line) == "" || line[0] == '*' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if line[0] != ' ' {
+			fields = fields[1:] // label
+		}
+		op, operand := fields[0], ""
+		if len(fields) > 1 {
+			operand = fields[1]
+		}
+
+		switch op {
+		case "EQU", "END":
+		case "ORIG":
+			if n, found := strings.CutPrefix(operand, "*+"); found {
+				skip, _ := strconv.Atoi(n)
+				location += skip
+			} else {
+				location, _ = strconv.Atoi(operand)
+			}
+		case "CON", "ALF":
+			location++
+		default:
+			location++
+			for _, literal := range literalPattern.FindAllString(operand, -1) {
+				literals[literal] = true
+			}
+		}
+	}
+	return location + len(literals)
+}
+
+// oi methodoi pairnoun labels M1, M2, ... me th seira tous, wste kanena onoma
+// na mhn symptei me ta labels tou compiler (p.x. f kai ff -> FF) h na
+// ksepernaei tous 10 xarakthres tou MIXAL
 func (c *CodeGenerator) generateMethodLabels(ast *AST) {
+	count := 0
 	for _, method := range ast.Methods {
 		if method.Name == "main" {
 			c.methodLabels[method.Name] = "MAIN"
-		} else {
-			// metatroph se mixal label
-			c.methodLabels[method.Name] = strings.ToUpper(method.Name)
+			continue
 		}
+		count++
+		c.methodLabels[method.Name] = fmt.Sprintf("M%d", count)
 	}
 }
 
@@ -119,15 +616,25 @@ func (c *CodeGenerator) generateMainProgram(ast *AST) error {
 	}
 
 	// mixal entry point
-	c.output.WriteString("        ORIG  1000\n")
-	c.output.WriteString("MAIN    NOP\n")
+	c.output.WriteString(fmt.Sprintf("        ORIG  %d\n", CODE_START))
 
 	// paragwgh body ths main
-	if err := c.generateMethodBody(*mainMethod); err != nil {
+	body, frameSize, err := c.generateFrameBody(*mainMethod)
+	if err != nil {
 		return fmt.Errorf("error generating main method body: %w", err)
 	}
 
-	c.output.WriteString("        HLT\n")
+	// to frame ths main einai to prwto sto stack
+	if STACK_START+frameSize > STACK_END {
+		return fmt.Errorf("frame of main (%d words) does not fit in the stack", frameSize)
+	}
+	c.output.WriteString(fmt.Sprintf("%s    EQU   %d\n", c.frameLabel("main"), frameSize))
+	c.output.WriteString("MAIN    NOP\n")
+	c.output.WriteString(fmt.Sprintf("        ENT6  %d\n", STACK_START))
+	c.output.WriteString(body)
+
+	// kathe return ths main erxetai edw me to apotelesma sto rA
+	c.output.WriteString(fmt.Sprintf("%s    HLT\n", c.exitLabel("main")))
 
 	return nil
 }
@@ -143,21 +650,54 @@ func (c *CodeGenerator) generateMethods(ast *AST) error {
 	return nil
 }
 
+// klhsh: o caller kanei JMP (rJ = epomenh entolh) me to rI6 sto neo frame,
+// h methodos apothikeuei to rJ sth thesh 0 tou frame kai kathe return
+// phgainei ston epilogo me timh sto rA
 func (c *CodeGenerator) generateMethod(method Method) error {
 	methodLabel := c.methodLabels[method.Name]
-	exitLabel := methodLabel + "X"
+	exitLabel := c.exitLabel(method.Name)
 
-	c.output.WriteString(fmt.Sprintf("%s    NOP\n", methodLabel))
-	c.output.WriteString(fmt.Sprintf("        STJ   %s\n", exitLabel))
-
-	if err := c.generateMethodBody(method); err != nil {
+	body, frameSize, err := c.generateFrameBody(method)
+	if err != nil {
 		return fmt.Errorf("error generating method body for %s: %w", method.Name, err)
 	}
+	if frameSize > STACK_END-STACK_START {
+		return fmt.Errorf("frame of %s (%d words) does not fit in the stack", method.Name, frameSize)
+	}
 
-	c.output.WriteString(fmt.Sprintf("%s    JMP   *\n", exitLabel))
+	// to onoma ths methodou ws sxolio, giati to label den to deixnei
+	c.output.WriteString(fmt.Sprintf("* %s\n", method.Name))
+	c.output.WriteString(fmt.Sprintf("%s    EQU   %d\n", c.frameLabel(method.Name), frameSize))
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", methodLabel))
+	c.output.WriteString("        STJ   0,6\n")
+	c.output.WriteString(body)
+
+	// epistrofh sth dieythinsh pou apothikeuthike sto frame
+	c.output.WriteString(fmt.Sprintf("%s    LD5   0,6(0:2)\n", exitLabel))
+	c.output.WriteString("        JMP   0,5\n")
 	return nil
 }
 
+// paragei to body ths methodou ksexwrista, wste to megethos tou frame
+// (metavlhtes kai temps) na einai gnwsto prin ton kwdika
+func (c *CodeGenerator) generateFrameBody(method Method) (string, int, error) {
+	saved := c.output.String()
+	c.output.Reset()
+
+	c.scope = c.symbolTables[method.Name]
+	c.tempBase = c.scope.FrameSize + 1
+	c.tempCounter = 0
+	c.maxTemps = 0
+
+	err := c.generateMethodBody(method)
+	body := c.output.String()
+
+	c.output.Reset()
+	c.output.WriteString(saved)
+
+	return body, c.tempBase + c.maxTemps, err
+}
+
 func (c *CodeGenerator) generateMethodBody(method Method) error {
 	// paragwgh dhlwsewn metavlhtwn
 	for _, decl := range method.Body.Declarations {
@@ -178,6 +718,23 @@ func (c *CodeGenerator) generateMethodBody(method Method) error {
 
 func (c *CodeGenerator) generateDeclaration(decl Declaration, methodName string) error {
 	for _, variable := range decl.Variables {
+		if text, ok := variable.InitialValue.(*StringLiteral); ok && variable.Size > 0 {
+			// antigrafh tou keimenou apo ta dedomena sto frame me MOVE
+			_, offset, _ := c.lookupLocal(methodName, variable.Name)
+			label := c.newLabel("STR")
+			c.dataBlocks = append(c.dataBlocks, dataBlock{label, stringData(decl.Type, text.Value, variable.Size)})
+
+			c.output.WriteString(fmt.Sprintf("        ENT1  %d,6\n", offset))
+			for moved := 0; moved < variable.Size; moved += MAX_MOVE {
+				source := label
+				if moved > 0 {
+					source = fmt.Sprintf("%s+%d", label, moved)
+				}
+				c.output.WriteString(fmt.Sprintf("        MOVE  %s(%d)\n", source, min(MAX_MOVE, variable.Size-moved)))
+			}
+			continue
+		}
+
 		if variable.InitialValue != nil {
 			// arxikopoihsh: var = initialValue
 			if err := c.generateExpression(variable.InitialValue, methodName); err != nil {
@@ -185,9 +742,8 @@ func (c *CodeGenerator) generateDeclaration(decl Declaration, methodName string)
 			}
 
 			// apothikeush apotelesmatos
-			varName := c.makeVariableName(methodName, variable.Name)
-			varAddr := c.addressMap[varName]
-			c.output.WriteString(fmt.Sprintf("        STA   %d\n", varAddr))
+			symbol, offset, _ := c.lookupLocal(methodName, variable.Name)
+			c.storeValue(c.frameAddress(offset), symbol.Type)
 		}
 	}
 	return nil
@@ -203,21 +759,41 @@ func (c *CodeGenerator) generateStatement(stmt Statement, methodName string) err
 		return c.generateIfStatement(s, methodName)
 	case *WhileStatement:
 		return c.generateWhileStatement(s, methodName)
+	case *ForStatement:
+		return c.generateForStatement(s, methodName)
+	case *DoWhileStatement:
+		return c.generateDoWhileStatement(s, methodName)
+	case *SwitchStatement:
+		return c.generateSwitchStatement(s, methodName)
+	case *LabeledStatement:
+		return c.generateLabeledStatement(s, methodName)
 	case *BreakStatement:
 		return c.generateBreakStatement(s)
+	case *ContinueStatement:
+		return c.generateContinueStatement(s)
 	case *BlockStatement:
 		return c.generateBlock(s.Block, methodName)
+	case *AsmStatement:
+		return c.generateAsmStatement(s, methodName)
+	case *ExpressionStatement:
+		// h timh pou afhnei sto rA h klhsh den xrhsimopoieitai
+		return c.generateExpression(s.Expression, methodName)
 	default:
 		return fmt.Errorf("unsupported statement type: %T", stmt)
 	}
 }
 
 func (c *CodeGenerator) generateReturnStatement(stmt *ReturnStatement, methodName string) error {
-	//kanonikh ekfrash return
-	if err := c.generateExpression(stmt.Expression, methodName); err != nil {
-		return fmt.Errorf("error generating return value: %w", err)
+	//kanonikh ekfrash return, to apotelesma menei sto rA
+	if stmt.Expression != nil {
+		if err := c.generateExpression(stmt.Expression, methodName); err != nil {
+			return fmt.Errorf("error generating return value: %w", err)
+		}
 	}
 
+	// goto epilogo ths methodou
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", c.exitLabel(methodName)))
+
 	return nil
 }
 
@@ -227,31 +803,89 @@ func (c *CodeGenerator) generateAssignment(stmt *Assignment, methodName string)
 		return err
 	}
 
-	// apothikeush apotelesmatos
-	var varAddr int
-	var found bool
-
-	// ✅ FIX: First check if it's a variable
-	varName := c.makeVariableName(methodName, stmt.Variable)
-	if addr, exists := c.addressMap[varName]; exists {
-		varAddr = addr
-		found = true
-	} else {
-		// ✅ FIX: If not a variable, check if it's a parameter
-		symbolTable := c.symbolTables[methodName]
-		paramAddr := c.findParameterByName(methodName, stmt.Variable, symbolTable)
-		if paramAddr != -1 {
-			varAddr = paramAddr
-			found = true
+	// pedio ths lekshs: ta ypoloipa bytes menoun ws exoun
+	if stmt.Field != nil {
+		var addr string
+		if stmt.Index != nil {
+			valueTemp := c.allocateTemp()
+			defer c.releaseTemp()
+			c.output.WriteString(fmt.Sprintf("        STA   %s\n", valueTemp))
+
+			elemAddr, err := c.generateElementAddress(methodName, stmt.Variable, stmt.Index)
+			if err != nil {
+				return err
+			}
+			c.output.WriteString(fmt.Sprintf("        LDA   %s\n", valueTemp))
+			addr = elemAddr
+		} else {
+			varAddr, err := c.fieldAddress(methodName, stmt.Variable, nil, stmt.Member)
+			if err != nil {
+				return err
+			}
+			addr = varAddr
+		}
+		c.output.WriteString(fmt.Sprintf("        STA   %s(%d:%d)\n", addr, stmt.Field.Left, stmt.Field.Right))
+		return nil
+	}
+
+	// melos struct: to LD1 gia tis parametrous den peirazei to rA
+	if stmt.Member != "" {
+		addr, err := c.memberAddress(methodName, stmt.Variable, stmt.Member)
+		if err != nil {
+			return err
+		}
+		c.storeValue(addr, c.memberType(methodName, stmt.Variable, stmt.Member))
+		return nil
+	}
+
+	// stoixeio pinaka: h timh perimenei se temp oso ypologizetai o deikths
+	targetType := c.symbolType(methodName, stmt.Variable)
+	if stmt.Index != nil {
+		elemType := elementType(targetType)
+		valueTemp := c.allocateValueTemp(elemType)
+		defer c.releaseValueTemp(elemType)
+		c.storeValue(valueTemp, elemType)
+
+		elemAddr, err := c.generateElementAddress(methodName, stmt.Variable, stmt.Index)
+		if err != nil {
+			return err
 		}
+		c.loadValue(valueTemp, elemType)
+		c.storeValue(elemAddr, elemType)
+		return nil
 	}
 
+	// apothikeush apotelesmatos
+	varAddr, found := c.valueAddress(methodName, stmt.Variable)
 	if !found {
 		return fmt.Errorf("variable or parameter '%s' not found in method '%s'", stmt.Variable, methodName)
 	}
 
-	c.output.WriteString(fmt.Sprintf("        STA   %d\n", varAddr))
+	c.storeValue(varAddr, targetType)
+
+	return nil
+}
+
+// oi grammes tou asm pernane ws exoun, me tis dieythinseis sth thesh twn %onoma
+func (c *CodeGenerator) generateAsmStatement(stmt *AsmStatement, methodName string) error {
+	c.output.WriteString(fmt.Sprintf("* asm, line %d\n", stmt.Line))
+	for _, line := range stmt.Lines {
+		var err error
+		text := asmPlaceholder.ReplaceAllStringFunc(line.Text, func(placeholder string) string {
+			addr, found := c.resolveAddress(methodName, placeholder[1:])
+			if !found {
+				err = fmt.Errorf("variable or parameter '%s' not found in method '%s'", placeholder[1:], methodName)
+			}
+			return addr
+		})
+		if err != nil {
+			return err
+		}
 
+		op := strings.Fields(text)[0]
+		instruction := fmt.Sprintf("        %-6s%s", op, strings.TrimSpace(text[len(op):]))
+		c.output.WriteString(strings.TrimRight(instruction, " ") + "\n")
+	}
 	return nil
 }
 
@@ -302,8 +936,10 @@ func (c *CodeGenerator) generateWhileStatement(stmt *WhileStatement, methodName
 	loopLabel := c.newLabel("LOOP")
 	endLabel := c.newLabel("ENDLOOP")
 
-	// append emfoleyumena break labels
+	// append emfoleyumena break labels, to continue ksanaelegxei th synthikh
 	c.breakLabels = append(c.breakLabels, endLabel)
+	c.continueLabels = append(c.continueLabels, loopLabel)
+	defer c.bindLoopLabel(endLabel, loopLabel)()
 
 	c.output.WriteString(fmt.Sprintf("%s    NOP\n", loopLabel))
 
@@ -327,13 +963,119 @@ func (c *CodeGenerator) generateWhileStatement(stmt *WhileStatement, methodName
 	// telos loop
 	c.output.WriteString(fmt.Sprintf("%s    NOP\n", endLabel))
 
-	// afairesh break label apo stack
+	// afairesh break kai continue label apo stack
+	c.breakLabels = c.breakLabels[:len(c.breakLabels)-1]
+	c.continueLabels = c.continueLabels[:len(c.continueLabels)-1]
+
+	return nil
+}
+
+// init, meta LOOP: synthiki, body, STEP: vhma kai JMP LOOP
+func (c *CodeGenerator) generateForStatement(stmt *ForStatement, methodName string) error {
+	loopLabel := c.newLabel("LOOP")
+	stepLabel := c.newLabel("STEP")
+	endLabel := c.newLabel("ENDLOOP")
+
+	if stmt.Init != nil {
+		if err := c.generateStatement(stmt.Init, methodName); err != nil {
+			return fmt.Errorf("error generating for initialization: %w", err)
+		}
+	}
+
+	// to continue phgainei sto vhma
+	c.breakLabels = append(c.breakLabels, endLabel)
+	c.continueLabels = append(c.continueLabels, stepLabel)
+	defer c.bindLoopLabel(endLabel, stepLabel)()
+
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", loopLabel))
+
+	// xwris synthiki o broxgos teleiwnei mono me break h return
+	if stmt.Condition != nil {
+		if err := c.generateExpression(stmt.Condition, methodName); err != nil {
+			return fmt.Errorf("error generating for condition: %w", err)
+		}
+		c.output.WriteString(fmt.Sprintf("        JAZ   %s\n", endLabel))
+	}
+
+	if err := c.generateStatement(stmt.Body, methodName); err != nil {
+		return fmt.Errorf("error generating for body: %w", err)
+	}
+
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", stepLabel))
+	if stmt.Step != nil {
+		if err := c.generateStatement(stmt.Step, methodName); err != nil {
+			return fmt.Errorf("error generating for step: %w", err)
+		}
+	}
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", loopLabel))
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", endLabel))
+
 	c.breakLabels = c.breakLabels[:len(c.breakLabels)-1]
+	c.continueLabels = c.continueLabels[:len(c.continueLabels)-1]
 
 	return nil
 }
 
-func (c *CodeGenerator) generateBreakStatement(_ *BreakStatement) error {
+// LOOP: body, COND: synthiki kai epistrofh sto LOOP oso einai true
+func (c *CodeGenerator) generateDoWhileStatement(stmt *DoWhileStatement, methodName string) error {
+	loopLabel := c.newLabel("LOOP")
+	condLabel := c.newLabel("COND")
+	endLabel := c.newLabel("ENDLOOP")
+
+	// to continue phgainei sth synthiki
+	c.breakLabels = append(c.breakLabels, endLabel)
+	c.continueLabels = append(c.continueLabels, condLabel)
+	defer c.bindLoopLabel(endLabel, condLabel)()
+
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", loopLabel))
+	if err := c.generateStatement(stmt.Body, methodName); err != nil {
+		return fmt.Errorf("error generating do body: %w", err)
+	}
+
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", condLabel))
+	if err := c.generateExpression(stmt.Condition, methodName); err != nil {
+		return fmt.Errorf("error generating do-while condition: %w", err)
+	}
+	c.output.WriteString(fmt.Sprintf("        JANZ  %s\n", loopLabel))
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", endLabel))
+
+	c.breakLabels = c.breakLabels[:len(c.breakLabels)-1]
+	c.continueLabels = c.continueLabels[:len(c.continueLabels)-1]
+
+	return nil
+}
+
+// etiketa: to statement einai broxgos pou th syndeei me ta labels tou
+func (c *CodeGenerator) generateLabeledStatement(stmt *LabeledStatement, methodName string) error {
+	c.pendingLabel = stmt.Label
+	defer func() { c.pendingLabel = "" }()
+	return c.generateStatement(stmt.Statement, methodName)
+}
+
+// an o broxgos exei etiketa, syndeei to onoma ths me ta labels tou
+// kai epistrefei th synarthsh pou th svhnei sto telos tou broxgou
+func (c *CodeGenerator) bindLoopLabel(breakLabel, continueLabel string) func() {
+	name := c.pendingLabel
+	c.pendingLabel = ""
+	if name == "" {
+		return func() {}
+	}
+
+	c.loopTargets[name] = loopTarget{breakLabel: breakLabel, continueLabel: continueLabel}
+	return func() { delete(c.loopTargets, name) }
+}
+
+func (c *CodeGenerator) generateBreakStatement(stmt *BreakStatement) error {
+	// break outer: eksodos apo ton broxgo me thn etiketa
+	if stmt.Label != "" {
+		target, exists := c.loopTargets[stmt.Label]
+		if !exists {
+			return fmt.Errorf("break to unknown label '%s'", stmt.Label)
+		}
+		c.output.WriteString(fmt.Sprintf("        JMP   %s\n", target.breakLabel))
+		return nil
+	}
+
 	if len(c.breakLabels) == 0 {
 		return fmt.Errorf("break statement outside of loop")
 	}
@@ -345,7 +1087,126 @@ func (c *CodeGenerator) generateBreakStatement(_ *BreakStatement) error {
 	return nil
 }
 
+// puknes times (to evros ws to diplo twn case) me pinaka almatwn, alliws
+// me alysida CMPA/JE, ta swmata akolouthoun me th seira tous (fallthrough)
+func (c *CodeGenerator) generateSwitchStatement(stmt *SwitchStatement, methodName string) error {
+	endLabel := c.newLabel("ENDSW")
+	defaultLabel := endLabel
+
+	// etiketes kai times twn case
+	caseLabels := make([]string, len(stmt.Cases))
+	targets := make(map[int]string)
+	low, high := 0, 0
+	for i, switchCase := range stmt.Cases {
+		caseLabels[i] = c.newLabel("CASE")
+		if switchCase.Value == nil {
+			defaultLabel = caseLabels[i]
+			continue
+		}
+
+		value, ok := evaluateConstant(switchCase.Value, c.scope)
+		if !ok {
+			return fmt.Errorf("case label at line %d is not constant", switchCase.Line)
+		}
+		if len(targets) == 0 {
+			low, high = value, value
+		}
+		low, high = min(low, value), max(high, value)
+		targets[value] = caseLabels[i]
+	}
+
+	if err := c.generateExpression(stmt.Expression, methodName); err != nil {
+		return fmt.Errorf("error generating switch expression: %w", err)
+	}
+
+	span := high - low + 1
+	if len(targets) >= 3 && span <= 2*len(targets) {
+		// ektos oriwn sto default, meta rI1 = timh - low kai JMP TABLE,1
+		tableLabel := c.newLabel("SWTAB")
+		c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", low))
+		c.output.WriteString(fmt.Sprintf("        JL    %s\n", defaultLabel))
+		c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", high))
+		c.output.WriteString(fmt.Sprintf("        JG    %s\n", defaultLabel))
+		if low != 0 {
+			c.output.WriteString(fmt.Sprintf("        SUB   =%d=\n", low))
+		}
+
+		indexTemp := c.allocateTemp()
+		c.output.WriteString(fmt.Sprintf("        STA   %s\n", indexTemp))
+		c.output.WriteString(fmt.Sprintf("        LD1   %s\n", indexTemp))
+		c.releaseTemp()
+		c.output.WriteString(fmt.Sprintf("        JMP   %s,1\n", tableLabel))
+
+		// mia leksh JMP gia kathe timh tou evrous, ta kena sto default
+		for value := low; value <= high; value++ {
+			target, exists := targets[value]
+			if !exists {
+				target = defaultLabel
+			}
+			if value == low {
+				c.output.WriteString(fmt.Sprintf("%s    JMP   %s\n", tableLabel, target))
+			} else {
+				c.output.WriteString(fmt.Sprintf("        JMP   %s\n", target))
+			}
+		}
+	} else {
+		for i, switchCase := range stmt.Cases {
+			if switchCase.Value == nil {
+				continue
+			}
+			value, _ := evaluateConstant(switchCase.Value, c.scope)
+			c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", value))
+			c.output.WriteString(fmt.Sprintf("        JE    %s\n", caseLabels[i]))
+		}
+		c.output.WriteString(fmt.Sprintf("        JMP   %s\n", defaultLabel))
+	}
+
+	// to break vgainei apo to switch, to continue afhnetai ston broxgo
+	c.breakLabels = append(c.breakLabels, endLabel)
+	for i, switchCase := range stmt.Cases {
+		c.output.WriteString(fmt.Sprintf("%s    NOP\n", caseLabels[i]))
+		for _, caseStmt := range switchCase.Statements {
+			if err := c.generateStatement(caseStmt, methodName); err != nil {
+				return fmt.Errorf("error generating case body: %w", err)
+			}
+		}
+	}
+	c.breakLabels = c.breakLabels[:len(c.breakLabels)-1]
+
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", endLabel))
+	return nil
+}
+
+func (c *CodeGenerator) generateContinueStatement(stmt *ContinueStatement) error {
+	// continue outer: epomenh epanalhpsh tou broxgou me thn etiketa
+	if stmt.Label != "" {
+		target, exists := c.loopTargets[stmt.Label]
+		if !exists {
+			return fmt.Errorf("continue to unknown label '%s'", stmt.Label)
+		}
+		c.output.WriteString(fmt.Sprintf("        JMP   %s\n", target.continueLabel))
+		return nil
+	}
+
+	if len(c.continueLabels) == 0 {
+		return fmt.Errorf("continue statement outside of loop")
+	}
+
+	// goto epomenh epanalhpsh tou plhsiesterou brongxou
+	continueLabel := c.continueLabels[len(c.continueLabels)-1]
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", continueLabel))
+
+	return nil
+}
+
 func (c *CodeGenerator) generateBlock(block Block, methodName string) error {
+	// to block exei diko tou scope
+	if block.Scope != nil {
+		outer := c.scope
+		c.scope = block.Scope
+		defer func() { c.scope = outer }()
+	}
+
 	// paragwgh dhlwsewn
 	for _, decl := range block.Declarations {
 		if err := c.generateDeclaration(decl, methodName); err != nil {
@@ -364,7 +1225,11 @@ func (c *CodeGenerator) generateBlock(block Block, methodName string) error {
 }
 
 func (c *CodeGenerator) generateExpression(expr Expression, methodName string) error {
-	symbolTable := c.symbolTables[methodName]
+	// oi stathere ekfraseis ypologizontai kata th metaglwttish
+	if value, ok := evaluateConstant(expr, c.scope); ok {
+		c.loadConstant(value, c.expressionType(expr, methodName))
+		return nil
+	}
 
 	switch e := expr.(type) {
 	case *NumberLiteral:
@@ -372,7 +1237,7 @@ func (c *CodeGenerator) generateExpression(expr Expression, methodName string) e
 		if err != nil {
 			return fmt.Errorf("invalid number literal '%s': %w", e.Value, err)
 		}
-		c.output.WriteString(fmt.Sprintf("        LDA   =%d=\n", value))
+		c.loadConstant(value, literalType(e))
 		return nil
 
 	case *BooleanLiteral:
@@ -384,25 +1249,48 @@ func (c *CodeGenerator) generateExpression(expr Expression, methodName string) e
 		return nil
 
 	case *Identifier:
-		varName := c.makeVariableName(methodName, e.Name)
-		if varAddr, exists := c.addressMap[varName]; exists {
-			c.output.WriteString(fmt.Sprintf("        LDA   %d\n", varAddr))
-			return nil
-		}
-		// xrhshmopoiw symbol table gia parametrous
-		paramAddr := c.findParameterByName(methodName, e.Name, symbolTable)
-		if paramAddr != -1 {
-			c.output.WriteString(fmt.Sprintf("        LDA   %d\n", paramAddr))
+		if varAddr, exists := c.valueAddress(methodName, e.Name); exists {
+			c.loadValue(varAddr, c.symbolType(methodName, e.Name))
 			return nil
 		}
 		return fmt.Errorf("undefined variable or parameter '%s' in method '%s'", e.Name, methodName)
 
+	case *IndexExpression:
+		elemAddr, err := c.generateElementAddress(methodName, e.Name, e.Index)
+		if err != nil {
+			return err
+		}
+		c.loadValue(elemAddr, c.expressionType(e, methodName))
+		return nil
+
+	case *FieldExpression:
+		addr, err := c.fieldAddress(methodName, e.Name, e.Index, e.Member)
+		if err != nil {
+			return err
+		}
+		c.output.WriteString(fmt.Sprintf("        LDA   %s(%d:%d)\n", addr, e.Field.Left, e.Field.Right))
+		return nil
+
+	case *MemberExpression:
+		addr, err := c.memberAddress(methodName, e.Name, e.Member)
+		if err != nil {
+			return err
+		}
+		c.loadValue(addr, c.expressionType(e, methodName))
+		return nil
+
+	case *StringLiteral:
+		return fmt.Errorf("string literal at line %d can only initialize an array", e.Line)
+
 	case *BinaryExpression:
 		return c.generateBinaryExpression(e, methodName)
 
 	case *UnaryExpression:
 		return c.generateUnaryExpression(e, methodName)
 
+	case *CastExpression:
+		return c.generateCastExpression(e, methodName)
+
 	case *MethodCall:
 		return c.generateMethodCall(e, methodName)
 
@@ -412,44 +1300,24 @@ func (c *CodeGenerator) generateExpression(expr Expression, methodName string) e
 }
 
 func (c *CodeGenerator) generateBinaryExpression(expr *BinaryExpression, methodName string) error {
-	if leftIdent, ok := expr.Left.(*Identifier); ok {
-		if rightIdent, ok := expr.Right.(*Identifier); ok {
-			symbolTable := c.symbolTables[methodName]
-
-			leftAddr := c.findParameterByName(methodName, leftIdent.Name, symbolTable)
-			if leftAddr == -1 {
-				// an oxi parameter, psaxnoume gia variable
-				varName := c.makeVariableName(methodName, leftIdent.Name)
-				if addr, exists := c.addressMap[varName]; exists {
-					leftAddr = addr
-				}
-			}
-
-			rightAddr := c.findParameterByName(methodName, rightIdent.Name, symbolTable)
-			if rightAddr == -1 {
-				// an oxi parameter, psaxnoume gia variable
-				varName := c.makeVariableName(methodName, rightIdent.Name)
-				if addr, exists := c.addressMap[varName]; exists {
-					rightAddr = addr
-				}
-			}
+	if expr.Operator == "&&" || expr.Operator == "||" {
+		return c.generateLogicalExpression(expr, methodName)
+	}
+	if isShift(expr.Operator) {
+		return c.generateShift(expr.Operator, expr.Left, expr.Right, methodName)
+	}
 
-			if leftAddr != -1 && rightAddr != -1 {
-				c.output.WriteString(fmt.Sprintf("        LDA   %d\n", leftAddr))
-
-				switch expr.Operator {
-				case "+":
-					c.output.WriteString(fmt.Sprintf("        ADD   %d\n", rightAddr))
-				case "-":
-					c.output.WriteString(fmt.Sprintf("        SUB   %d\n", rightAddr))
-				case "*":
-					c.output.WriteString(fmt.Sprintf("        MUL   %d\n", rightAddr))
-				case "/":
-					c.output.WriteString(fmt.Sprintf("        DIV   %d\n", rightAddr))
-				case "==", "!=", "<", "<=", ">", ">=":
-					return c.generateComparison(expr.Operator, rightAddr)
-				}
-				return nil
+	// to long xreiazetai dyo lekseis gia kathe telesth, ara panta temps,
+	// kai oi parametroi ref tha xreiazontan kai oi dyo to rI1
+	operandType := expr.OperandType
+	if leftIdent, ok := expr.Left.(*Identifier); ok && operandType != "long" && !c.isRefParameter(methodName, leftIdent.Name) {
+		if rightIdent, ok := expr.Right.(*Identifier); ok && !c.isRefParameter(methodName, rightIdent.Name) {
+			leftAddr, leftFound := c.resolveAddress(methodName, leftIdent.Name)
+			rightAddr, rightFound := c.resolveAddress(methodName, rightIdent.Name)
+
+			if leftFound && rightFound {
+				c.output.WriteString(fmt.Sprintf("        LDA   %s\n", leftAddr))
+				return c.generateOperation(expr.Operator, expr.OperandType, rightAddr)
 			}
 		}
 	}
@@ -460,8 +1328,9 @@ func (c *CodeGenerator) generateBinaryExpression(expr *BinaryExpression, methodN
 	}
 
 	// apothikeush aristerou apotelesmatos proswrina
-	leftTemp := c.allocateTemp()
-	c.output.WriteString(fmt.Sprintf("        STA   %d\n", leftTemp))
+	leftTemp := c.allocateValueTemp(operandType)
+	defer c.releaseValueTemp(operandType)
+	c.storeValue(leftTemp, operandType)
 
 	// deksia pleura
 	if err := c.generateExpression(expr.Right, methodName); err != nil {
@@ -469,36 +1338,222 @@ func (c *CodeGenerator) generateBinaryExpression(expr *BinaryExpression, methodN
 	}
 
 	// apothikeush deksiou apotelesmatos proswrina
-	rightTemp := c.allocateTemp()
-	c.output.WriteString(fmt.Sprintf("        STA   %d\n", rightTemp))
+	rightTemp := c.allocateValueTemp(operandType)
+	defer c.releaseValueTemp(operandType)
+	c.storeValue(rightTemp, operandType)
 
-	// fortwsh aristerou apotelesmatos sto rA
-	c.output.WriteString(fmt.Sprintf("        LDA   %d\n", leftTemp))
+	// fortwsh aristerou apotelesmatos sto rA (kai rX gia long)
+	c.loadValue(leftTemp, operandType)
 
 	// praksh
-	switch expr.Operator {
+	return c.generateOperation(expr.Operator, operandType, rightTemp)
+}
+
+// x << n me SLA kai x >> n me SRA, to rotl kai to rotr me SLC kai SRC sto rAX
+// me to x kai sto rA kai sto rX (ara h peristrofh ginetai sta 5 bytes tou rA)
+func (c *CodeGenerator) generateShift(op string, value, count Expression, methodName string) error {
+	shifts := map[string]string{"<<": "SLA", ">>": "SRA", "rotl": "SLC", "rotr": "SRC"}
+	rotate := op == "rotl" || op == "rotr"
+
+	if err := c.generateExpression(value, methodName); err != nil {
+		return err
+	}
+
+	// stathero plhthos: meta ta 5 bytes h metatopish dinei 0 kai h peristrofh epanalamvanetai
+	if n, ok := evaluateConstant(count, c.scope); ok && n >= 0 {
+		if rotate {
+			n %= mix.WordBytes
+			temp := c.allocateTemp()
+			c.output.WriteString(fmt.Sprintf("        STA   %s\n", temp))
+			c.output.WriteString(fmt.Sprintf("        LDX   %s\n", temp))
+			c.releaseTemp()
+		}
+		if n > 0 {
+			c.output.WriteString(fmt.Sprintf("        %-5s %d\n", shifts[op], min(n, mix.WordBytes)))
+		}
+		return nil
+	}
+
+	valueTemp := c.allocateTemp()
+	defer c.releaseTemp()
+	c.output.WriteString(fmt.Sprintf("        STA   %s\n", valueTemp))
+
+	// to plhthos sto rI1: mexri 5 gia metatopish, modulo 5 gia peristrofh
+	if err := c.generateExpression(count, methodName); err != nil {
+		return err
+	}
+	c.output.WriteString(fmt.Sprintf("        JAN   %s\n", c.runtimeError("ERRSHF")))
+	countTemp := c.allocateTemp()
+	if rotate {
+		c.output.WriteString("        SRAX  5\n")
+		c.output.WriteString(fmt.Sprintf("        DIV   =%d=\n", mix.WordBytes))
+		c.output.WriteString(fmt.Sprintf("        STX   %s\n", countTemp))
+	} else {
+		c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", mix.WordBytes))
+		c.output.WriteString("        JLE   *+2\n")
+		c.output.WriteString(fmt.Sprintf("        LDA   =%d=\n", mix.WordBytes))
+		c.output.WriteString(fmt.Sprintf("        STA   %s\n", countTemp))
+	}
+	c.output.WriteString(fmt.Sprintf("        LD1   %s\n", countTemp))
+	c.releaseTemp()
+
+	c.output.WriteString(fmt.Sprintf("        LDA   %s\n", valueTemp))
+	if rotate {
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", valueTemp))
+	}
+	c.output.WriteString(fmt.Sprintf("        %-5s 0,1\n", shifts[op]))
+	return nil
+}
+
+// short-circuit: to deksi meros ypologizetai mono an xreiazetai,
+// to apotelesma sto rA einai 0 h 1
+func (c *CodeGenerator) generateLogicalExpression(expr *BinaryExpression, methodName string) error {
+	// && stamataei sto prwto false, || sto prwto true
+	shortLabel := c.newLabel("FALSE")
+	jump := "JAZ"
+	shortValue, fullValue := 0, 1
+	if expr.Operator == "||" {
+		shortLabel = c.newLabel("TRUE")
+		jump = "JANZ"
+		shortValue, fullValue = 1, 0
+	}
+	endLabel := c.newLabel("ENDLOG")
+
+	for _, operand := range []Expression{expr.Left, expr.Right} {
+		if err := c.generateExpression(operand, methodName); err != nil {
+			return fmt.Errorf("error generating logical operand: %w", err)
+		}
+		c.output.WriteString(fmt.Sprintf("        %-5s %s\n", jump, shortLabel))
+	}
+
+	c.output.WriteString(fmt.Sprintf("        LDA   =%d=\n", fullValue))
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", endLabel))
+	c.output.WriteString(fmt.Sprintf("%s    LDA   =%d=\n", shortLabel, shortValue))
+	c.output.WriteString(fmt.Sprintf("%s    NOP\n", endLabel))
+
+	return nil
+}
+
+// praksh rA op rightAddr, to apotelesma menei sto rA
+//
+// '+', '-': apotelesma pou den xwraei se mia leksh anavei to overflow toggle
+// (runtime error ERROVF).
+// '*': to MUL afhnei to ginomeno sto rAX, an to rA den einai 0 to apotelesma
+// den xwraei se mia leksh (runtime error ERROVF), alliws to SLAX 5 to ferei sto rA.
+// '/': akeraia diairesh me apokoph pros to 0, to proshmo tou phlikou einai
+// to ginomeno twn proshmwn. To SRAX 5 vazei ton diaireteo sto rX (rA = 0 me to
+// proshmo tou) gia to DIV, diairesh me 0 dinei runtime error ERRDIV.
+// '%': to ypoloipo tou DIV apo to rX, me to proshmo tou diaireteou
+// (a % b == a - (a / b) * b, p.x. -7 % 2 == -1 kai 7 % -2 == 1).
+// Gia float oi prakseis ginontai me FADD, FSUB, FMUL, FDIV.
+func (c *CodeGenerator) generateOperation(op, operandType, rightAddr string) error {
+	if operandType == "float" && !isRelational(op) {
+		return c.generateFloatOperation(op, rightAddr)
+	}
+	if operandType == "long" && !isRelational(op) {
+		return c.generateLongOperation(op, rightAddr)
+	}
+
+	switch op {
 	case "+":
-		c.output.WriteString(fmt.Sprintf("        ADD   %d\n", rightTemp))
+		c.output.WriteString(fmt.Sprintf("        ADD   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JOV   %s\n", c.runtimeError("ERROVF")))
 	case "-":
-		c.output.WriteString(fmt.Sprintf("        SUB   %d\n", rightTemp))
+		c.output.WriteString(fmt.Sprintf("        SUB   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JOV   %s\n", c.runtimeError("ERROVF")))
 	case "*":
-		c.output.WriteString(fmt.Sprintf("        MUL   %d\n", rightTemp))
+		c.output.WriteString(fmt.Sprintf("        MUL   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JANZ  %s\n", c.runtimeError("ERROVF")))
+		c.output.WriteString("        SLAX  5\n")
 	case "/":
-		c.output.WriteString(fmt.Sprintf("        DIV   %d\n", rightTemp))
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JXZ   %s\n", c.runtimeError("ERRDIV")))
+		c.output.WriteString("        SRAX  5\n")
+		c.output.WriteString(fmt.Sprintf("        DIV   %s\n", rightAddr))
+	case "%":
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JXZ   %s\n", c.runtimeError("ERRDIV")))
+		c.output.WriteString("        SRAX  5\n")
+		c.output.WriteString(fmt.Sprintf("        DIV   %s\n", rightAddr))
+
+		// metafora tou ypoloipou sto rA
+		remainder := c.allocateTemp()
+		c.output.WriteString(fmt.Sprintf("        STX   %s\n", remainder))
+		c.output.WriteString(fmt.Sprintf("        LDA   %s\n", remainder))
+		c.releaseTemp()
 	case "==", "!=", "<", "<=", ">", ">=":
-		return c.generateComparison(expr.Operator, rightTemp)
+		return c.generateComparison(op, operandType, rightAddr)
 	default:
-		return fmt.Errorf("unsupported operator: %s", expr.Operator)
+		return fmt.Errorf("unsupported operator: %s", op)
 	}
 
 	return nil
 }
 
-func (c *CodeGenerator) generateComparison(op string, rightAddr int) error {
+// praksh float rA op rightAddr, ekthetis pou den xwraei dinei ERROVF
+// kai diairesh me 0 (h leksh 0) ERRDIV
+func (c *CodeGenerator) generateFloatOperation(op string, rightAddr string) error {
+	switch op {
+	case "+":
+		c.output.WriteString(fmt.Sprintf("        FADD  %s\n", rightAddr))
+	case "-":
+		c.output.WriteString(fmt.Sprintf("        FSUB  %s\n", rightAddr))
+	case "*":
+		c.output.WriteString(fmt.Sprintf("        FMUL  %s\n", rightAddr))
+	case "/":
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", rightAddr))
+		c.output.WriteString(fmt.Sprintf("        JXZ   %s\n", c.runtimeError("ERRDIV")))
+		c.output.WriteString(fmt.Sprintf("        FDIV  %s\n", rightAddr))
+	default:
+		return fmt.Errorf("unsupported float operator: %s", op)
+	}
+	c.output.WriteString(fmt.Sprintf("        JOV   %s\n", c.runtimeError("ERROVF")))
+
+	return nil
+}
+
+// praksh long rAX op rightAddr me tis routines LADD, LSUB, LMUL, LDIV, LMOD,
+// pou pairnoun th dieythinsh tou deksiou telesth sto rI1
+func (c *CodeGenerator) generateLongOperation(op string, rightAddr string) error {
+	routines := map[string]string{"+": "LADD", "-": "LSUB", "*": "LMUL", "/": "LDIV", "%": "LMOD"}
+	entry, exists := routines[op]
+	if !exists {
+		return fmt.Errorf("unsupported long operator: %s", op)
+	}
+
+	// to LSUB einai mesa sto LADD kai to LMOD sto LDIV
+	switch entry {
+	case "LADD", "LSUB":
+		c.usedRoutines["LADD"] = true
+		c.runtimeError("ERROVF")
+	case "LMUL":
+		c.usedRoutines["LMUL"] = true
+		c.runtimeError("ERROVF")
+	default:
+		c.usedRoutines["LDIV"] = true
+		c.runtimeError("ERRDIV")
+	}
+
+	c.output.WriteString(fmt.Sprintf("        ENT1  %s\n", rightAddr))
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", entry))
+	return nil
+}
+
+// sygkrish: CMPA gia int/char/bool, FCMP gia float, gia long prwta ta
+// ypshla merh kai an einai isa ta xamhla (ta dyo merh exoun idio proshmo)
+func (c *CodeGenerator) generateComparison(op, operandType, rightAddr string) error {
 	trueLabel := c.newLabel("TRUE")
 	endLabel := c.newLabel("ENDCMP")
 
-	c.output.WriteString(fmt.Sprintf("        CMPA   %d\n", rightAddr))
+	compare := "CMPA"
+	if operandType == "float" {
+		compare = "FCMP"
+	}
+	c.output.WriteString(fmt.Sprintf("        %s   %s\n", compare, rightAddr))
+	if operandType == "long" {
+		c.output.WriteString("        JNE   *+2\n")
+		c.output.WriteString(fmt.Sprintf("        CMPX  %s\n", wordAfter(rightAddr)))
+	}
 
 	// goto vash apotelesmatos
 	switch op {
@@ -535,11 +1590,22 @@ func (c *CodeGenerator) generateUnaryExpression(expr *UnaryExpression, methodNam
 
 	switch expr.Operator {
 	case "-":
+		if c.expressionType(expr.Operand, methodName) == "long" {
+			// allagh proshmou kai stis dyo lekseis
+			tempAddr := c.allocateValueTemp("long")
+			c.storeValue(tempAddr, "long")
+			c.output.WriteString(fmt.Sprintf("        LDAN  %s\n", tempAddr))
+			c.output.WriteString(fmt.Sprintf("        LDXN  %s\n", wordAfter(tempAddr)))
+			c.releaseValueTemp("long")
+			break
+		}
+
 		// arithmitikh arnhsh ( -x = 0 - x )
 		tempAddr := c.allocateTemp()
-		c.output.WriteString(fmt.Sprintf("        STA   %d\n", tempAddr))
+		c.output.WriteString(fmt.Sprintf("        STA   %s\n", tempAddr))
 		c.output.WriteString("        LDA   =0=\n")
-		c.output.WriteString(fmt.Sprintf("        SUB   %d\n", tempAddr))
+		c.output.WriteString(fmt.Sprintf("        SUB   %s\n", tempAddr))
+		c.releaseTemp()
 
 	case "!":
 		// logikh arnhsh
@@ -561,67 +1627,524 @@ func (c *CodeGenerator) generateUnaryExpression(expr *UnaryExpression, methodNam
 	return nil
 }
 
+// int -> float me FLOT, float -> int me FIX (stroggylopoihsh ston plhsiestero),
+// int -> long: h timh pernaei sto rX kai to rA krataei mono to proshmo,
+// long -> int: to rA prepei na einai 0 (alliws ERROVF) kai h timh einai to rX
+func (c *CodeGenerator) generateCastExpression(expr *CastExpression, methodName string) error {
+	if err := c.generateExpression(expr.Expression, methodName); err != nil {
+		return err
+	}
+
+	switch {
+	case expr.FromType == "int" && expr.Type == "float":
+		c.output.WriteString("        FLOT\n")
+	case expr.FromType == "float" && expr.Type == "int":
+		c.output.WriteString("        FIX\n")
+		c.output.WriteString(fmt.Sprintf("        JOV   %s\n", c.runtimeError("ERROVF")))
+	case expr.FromType == "int" && expr.Type == "long":
+		temp := c.allocateTemp()
+		c.output.WriteString(fmt.Sprintf("        STA   %s\n", temp))
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", temp))
+		c.output.WriteString(fmt.Sprintf("        LDA   %s(0:0)\n", temp))
+		c.releaseTemp()
+	case expr.FromType == "long" && expr.Type == "int":
+		temp := c.allocateTemp()
+		c.output.WriteString(fmt.Sprintf("        JANZ  %s\n", c.runtimeError("ERROVF")))
+		c.output.WriteString(fmt.Sprintf("        STX   %s\n", temp))
+		c.output.WriteString(fmt.Sprintf("        LDA   %s\n", temp))
+		c.releaseTemp()
+	}
+	return nil
+}
+
+// ta builtins kaloun tis routines I/O, to orisma tou print sto rA
+// kai h dieythinsh tou keimenou tou printstr sto rI1
+func (c *CodeGenerator) generateBuiltinCall(expr *MethodCall, methodName string) error {
+	routine := ""
+	switch expr.Name {
+	case "print":
+		if err := c.generateExpression(expr.Arguments[0], methodName); err != nil {
+			return err
+		}
+		routine = "PRINT"
+
+	case "printstr":
+		text, ok := expr.Arguments[0].(*StringLiteral)
+		if !ok {
+			return fmt.Errorf("printstr at line %d expects a string literal", expr.Line)
+		}
+		label := c.newLabel("STR")
+		c.dataBlocks = append(c.dataBlocks, dataBlock{label, stringData("int", text.Value, LINE_WIDTH/mix.WordBytes)})
+		c.output.WriteString(fmt.Sprintf("        ENT1  %s\n", label))
+		routine = "PRSTR"
+
+	case "read":
+		// o arithmos pou den xwraei se leksh stamataei me ERROVF
+		c.runtimeError("ERROVF")
+		routine = "READ"
+
+	case "rotl", "rotr":
+		// xwris routine, ginontai me SLC/SRC
+		return c.generateShift(expr.Name, expr.Arguments[0], expr.Arguments[1], methodName)
+	}
+
+	c.usedRoutines[routine] = true
+	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", routine))
+	return nil
+}
+
 func (c *CodeGenerator) generateMethodCall(expr *MethodCall, methodName string) error {
+	if _, builtin := builtins[expr.Name]; builtin {
+		return c.generateBuiltinCall(expr, methodName)
+	}
+
+	// ta orismata ypologizontai prwta se temps tou caller, giati mia
+	// emfwleumenh klhsh xrhsimopoiei ton xwro panw apo to frame
+	method, exists := c.globalTable.Lookup(expr.Name)
+	if !exists {
+		return fmt.Errorf("undefined method '%s'", expr.Name)
+	}
+
+	argTemps := make([]string, len(expr.Arguments))
 	for i, arg := range expr.Arguments {
-		if err := c.generateExpression(arg, methodName); err != nil {
+		if method.PassedByAddress(i) {
+			// ref kai struct pernane me th dieythinsh tous
+			if err := c.generateReference(arg, methodName); err != nil {
+				return err
+			}
+		} else if err := c.generateExpression(arg, methodName); err != nil {
 			return err
 		}
 
-		paramAddr := c.getParameterAddress(expr.Name, i)
-		c.output.WriteString(fmt.Sprintf("        STA   %d\n", paramAddr))
+		argTemps[i] = c.allocateValueTemp(method.ParamSlotType(i))
+		c.storeValue(argTemps[i], method.ParamSlotType(i))
 	}
 
+	// to frame tou callee arxizei amesws meta to frame tou caller kai prepei
+	// na xwraei sto stack prin grafei kanena orisma se auto
+	frameLabel := c.frameLabel(methodName)
+	c.output.WriteString(fmt.Sprintf("        ENTA  %s+%s,6\n", frameLabel, c.frameLabel(expr.Name)))
+	c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", STACK_END))
+	c.output.WriteString(fmt.Sprintf("        JG    %s\n", c.runtimeError("ERRSTK")))
+
+	// antigrafh sto frame tou callee (oi parametroi long pianoun dyo theseis)
+	offset := 0
+	for i, temp := range argTemps {
+		slotType := method.ParamSlotType(i)
+		paramAddr := c.getParameterAddress(expr.Name, offset)
+		c.loadValue(temp, slotType)
+		c.storeValue(fmt.Sprintf("%s+%d,6", frameLabel, paramAddr), slotType)
+		offset += typeWords(slotType)
+	}
+	for i := len(argTemps) - 1; i >= 0; i-- {
+		c.releaseValueTemp(method.ParamSlotType(i))
+	}
+
+	// to JMP vazei th dieythinsh epistrofhs sto rJ, h timh epistrefetai sto rA
 	methodLabel := c.methodLabels[expr.Name]
+	c.output.WriteString(fmt.Sprintf("        INC6  %s\n", frameLabel))
 	c.output.WriteString(fmt.Sprintf("        JMP   %s\n", methodLabel))
+	c.output.WriteString(fmt.Sprintf("        DEC6  %s\n", frameLabel))
 
 	return nil
 }
 
 func (c *CodeGenerator) generateFooter() {
+	// routines twn builtins
+	for _, routine := range runtimeRoutines {
+		if c.usedRoutines[routine.Label] {
+			for _, line := range routine.Code {
+				c.output.WriteString(line + "\n")
+			}
+		}
+	}
+
+	// keimena twn topikwn pinakwn kai tou printstr
+	for _, block := range c.dataBlocks {
+		for i, line := range block.lines {
+			label := ""
+			if i == 0 {
+				label = block.label
+			}
+			c.output.WriteString(fmt.Sprintf("%-8s%s\n", label, line))
+		}
+	}
+
+	// runtime errors pou xrhsimopoihthikan
+	for _, rtErr := range runtimeErrors {
+		if c.usedErrors[rtErr.Label] {
+			c.output.WriteString(fmt.Sprintf("%s  ENTX  %d\n", rtErr.Label, rtErr.Code))
+			c.output.WriteString("        HLT\n")
+		}
+	}
+
 	c.output.WriteString("        END   MAIN")
 }
 
 // HELPERS
 
+// ta dyo merh enos long (ypshlo kai xamhlo) me to proshmo ths timhs
+func longWords(value int) (int, int) {
+	const base = MAX_WORD + 1
+	return value / base, value % base
+}
+
+// h epomenh leksh enos operand, p.x. 3,6 -> 3+1,6 kai 2000 -> 2000+1
+func wordAfter(addr string) string {
+	if address, index, found := strings.Cut(addr, ","); found {
+		return address + "+1," + index
+	}
+	return addr + "+1"
+}
+
+// lekseis enos keimenou gia pinaka typou elemType, symplhrwmenes me 0 mexri to size:
+// char -> enas kwdikos ana leksh (CON), int -> 5 xarakthres ana leksh (ALF)
+func stringData(elemType, text string, size int) []string {
+	var lines []string
+	if elemType == "char" {
+		for i := 0; i < len(text); i++ {
+			lines = append(lines, fmt.Sprintf("CON   %d", mix.CharCode(rune(text[i]))))
+		}
+	} else {
+		for i := 0; i < len(text); i += mix.WordBytes {
+			chunk := text[i:min(i+mix.WordBytes, len(text))]
+			lines = append(lines, fmt.Sprintf("ALF   \"%-5s\"", chunk))
+		}
+	}
+
+	for len(lines) < size {
+		lines = append(lines, "CON   0")
+	}
+	return lines
+}
+
 func (c *CodeGenerator) newLabel(prefix string) string {
 	label := fmt.Sprintf("%s%d", prefix, c.labelCounter)
 	c.labelCounter++
 	return label
 }
 
-func (c *CodeGenerator) allocateTemp() int {
-	tempAddr := TEMP_START + c.tempCounter
+// etiketa epilogou methodou (p.x. M1X, MAINX)
+func (c *CodeGenerator) exitLabel(methodName string) string {
+	return c.methodLabels[methodName] + "X"
+}
+
+// etiketa megethous frame (p.x. M1F EQU 4)
+func (c *CodeGenerator) frameLabel(methodName string) string {
+	return c.methodLabels[methodName] + "F"
+}
+
+// literal =W-value= tou MIXAL mesa se operand
+var literalPattern = regexp.MustCompile(`=[^=]*=`)
+
+// etiketa runtime error, to stub paragetai sto footer
+func (c *CodeGenerator) runtimeError(label string) string {
+	c.usedErrors[label] = true
+	return label
+}
+
+// dieythinsh sto trexon frame
+func (c *CodeGenerator) frameAddress(offset int) string {
+	return fmt.Sprintf("%d,6", offset)
+}
+
+// ta temps einai sto frame kai apeleytheronontai me th seira (san stack)
+func (c *CodeGenerator) allocateTemp() string {
+	tempAddr := c.frameAddress(c.tempBase + c.tempCounter)
 	c.tempCounter++
+	if c.tempCounter > c.maxTemps {
+		c.maxTemps = c.tempCounter
+	}
 
 	return tempAddr
 }
 
-func (c *CodeGenerator) makeParameterName(methodName string, index int) string {
-	return fmt.Sprintf("%s_param_%d", methodName, index)
+func (c *CodeGenerator) releaseTemp() {
+	c.tempCounter--
 }
 
-func (c *CodeGenerator) makeVariableName(methodName, varName string) string {
-	return fmt.Sprintf("%s_%s", methodName, varName)
+// ypologizei ton deikth me elegxo oriwn kai fortwnei to rI1 = FP + deikths
+// (h mono deikths gia global pinakes), epistrefei to operand tou stoixeiou (base,1)
+func (c *CodeGenerator) generateElementAddress(methodName, name string, index Expression) (string, error) {
+	symbol, base, local := c.lookupLocal(methodName, name)
+	exists := local
+	if !local {
+		symbol, exists = c.globalTable.Lookup(name)
+		base = c.globalAddress[name]
+	}
+	if !exists {
+		return "", fmt.Errorf("undefined array '%s' in method '%s'", name, methodName)
+	}
+
+	if err := c.generateExpression(index, methodName); err != nil {
+		return "", fmt.Errorf("error generating index of '%s': %w", name, err)
+	}
+
+	// 0 <= deikths < size
+	c.output.WriteString(fmt.Sprintf("        JAN   %s\n", c.runtimeError("ERRIDX")))
+	c.output.WriteString(fmt.Sprintf("        CMPA  =%d=\n", symbol.Size))
+	c.output.WriteString(fmt.Sprintf("        JGE   %s\n", c.runtimeError("ERRIDX")))
+
+	// rA -> rI1 mesw mnhmhs, meta prosthesh tou FP
+	indexTemp := c.allocateTemp()
+	c.output.WriteString(fmt.Sprintf("        STA   %s\n", indexTemp))
+	c.output.WriteString(fmt.Sprintf("        LD1   %s\n", indexTemp))
+	if typeWords(elementType(symbol.Type)) == 2 {
+		// ta stoixeia long pianoun dyo lekseis
+		c.output.WriteString("        INC1  0,1\n")
+	}
+	if local {
+		c.output.WriteString("        INC1  0,6\n")
+	}
+	c.releaseTemp()
+
+	return fmt.Sprintf("%d,1", base), nil
 }
 
-func (c *CodeGenerator) findParameterByName(methodName, paramName string, symbolTable *SymbolTable) int {
-	if symbol, exists := symbolTable.Symbols[paramName]; exists && symbol.Kind == "parameter" {
-		paramKey := c.makeParameterName(methodName, symbol.Offset)
-		if addr, exists := c.addressMap[paramKey]; exists {
-			return addr
+// dieythinsh ths lekshs enos pediou: metablhth, stoixeio pinaka h melos struct
+func (c *CodeGenerator) fieldAddress(methodName, name string, index Expression, member string) (string, error) {
+	if index != nil {
+		return c.generateElementAddress(methodName, name, index)
+	}
+	if member != "" {
+		return c.memberAddress(methodName, name, member)
+	}
+	addr, found := c.valueAddress(methodName, name)
+	if !found {
+		return "", fmt.Errorf("variable or parameter '%s' not found in method '%s'", name, methodName)
+	}
+	return addr, nil
+}
+
+// dieythinsh melous struct me stathero offset: sto frame, sth global mnhmh
+// h mesw tou rI1 gia parametro, pou krataei th dieythinsh tou struct
+func (c *CodeGenerator) memberAddress(methodName, name, member string) (string, error) {
+	symbol, offset, local := c.lookupLocal(methodName, name)
+	if !local {
+		global, exists := c.globalTable.Lookup(name)
+		if !exists {
+			return "", fmt.Errorf("undefined struct '%s' in method '%s'", name, methodName)
+		}
+		symbol, offset = global, c.globalAddress[name]
+	}
+
+	structMember, exists := symbol.Struct.Member(member)
+	if !exists {
+		return "", fmt.Errorf("struct '%s' has no member '%s'", symbol.Struct.Name, member)
+	}
+
+	switch {
+	case local && symbol.Kind == "parameter":
+		c.output.WriteString(fmt.Sprintf("        LD1   %s\n", c.frameAddress(offset)))
+		return fmt.Sprintf("%d,1", structMember.Offset), nil
+	case local:
+		return c.frameAddress(offset + structMember.Offset), nil
+	default:
+		return fmt.Sprintf("%d", offset+structMember.Offset), nil
+	}
+}
+
+// fortwnei sto rA th dieythinsh tou orismatos gia parametro ref h struct
+func (c *CodeGenerator) generateReference(arg Expression, methodName string) error {
+	var addr string
+	var err error
+	switch a := arg.(type) {
+	case *Identifier:
+		// oi parametroi ref kai struct krataane hdh th dieythinsh
+		if symbol, offset, local := c.lookupLocal(methodName, a.Name); local &&
+			symbol.Kind == "parameter" && (symbol.Ref || symbol.Struct != nil) {
+			c.output.WriteString(fmt.Sprintf("        LDA   %s\n", c.frameAddress(offset)))
+			return nil
+		}
+		var found bool
+		if addr, found = c.resolveAddress(methodName, a.Name); !found {
+			return fmt.Errorf("undefined variable or parameter '%s' in method '%s'", a.Name, methodName)
+		}
+	case *IndexExpression:
+		addr, err = c.generateElementAddress(methodName, a.Name, a.Index)
+	case *MemberExpression:
+		addr, err = c.memberAddress(methodName, a.Name, a.Member)
+	default:
+		return fmt.Errorf("argument %T has no address", arg)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.output.WriteString(fmt.Sprintf("        ENTA  %s\n", addr))
+	return nil
+}
+
+// parametros ref ths methodou
+func (c *CodeGenerator) isRefParameter(methodName, name string) bool {
+	symbol, _, exists := c.lookupLocal(methodName, name)
+	return exists && symbol.Ref
+}
+
+// dieythinsh ths timhs mias metablhths, gia parametro ref h timh einai
+// sth dieythinsh pou krataei h parametros (fortwnetai sto rI1)
+func (c *CodeGenerator) valueAddress(methodName, name string) (string, bool) {
+	if symbol, offset, exists := c.lookupLocal(methodName, name); exists && symbol.Ref {
+		c.output.WriteString(fmt.Sprintf("        LD1   %s\n", c.frameAddress(offset)))
+		return "0,1", true
+	}
+	return c.resolveAddress(methodName, name)
+}
+
+// typos tou melous enos struct
+func (c *CodeGenerator) memberType(methodName, name, member string) string {
+	symbol, _, exists := c.lookupLocal(methodName, name)
+	if !exists {
+		symbol, exists = c.globalTable.Lookup(name)
+	}
+	if !exists || symbol.Struct == nil {
+		return ""
+	}
+	structMember, _ := symbol.Struct.Member(member)
+	return structMember.Type
+}
+
+// typos ekfrashs meta th semantic analysh (ta promotions einai hdh CastExpression)
+func (c *CodeGenerator) expressionType(expr Expression, methodName string) string {
+	switch e := expr.(type) {
+	case *NumberLiteral:
+		return literalType(e)
+	case *FloatLiteral:
+		return "float"
+	case *BooleanLiteral:
+		return "bool"
+	case *CharLiteral:
+		return "char"
+	case *Identifier:
+		return c.symbolType(methodName, e.Name)
+	case *IndexExpression:
+		return elementType(c.symbolType(methodName, e.Name))
+	case *FieldExpression:
+		return "int"
+	case *MemberExpression:
+		return c.memberType(methodName, e.Name, e.Member)
+	case *CastExpression:
+		return e.Type
+	case *UnaryExpression:
+		if e.Operator == "!" {
+			return "bool"
+		}
+		return c.expressionType(e.Operand, methodName)
+	case *BinaryExpression:
+		if isRelational(e.Operator) || e.Operator == "&&" || e.Operator == "||" {
+			return "bool"
+		}
+		return e.OperandType
+	case *MethodCall:
+		if symbol, exists := builtins[e.Name]; exists {
+			return symbol.Type
+		}
+		if symbol, exists := c.globalTable.Lookup(e.Name); exists {
+			return symbol.Type
+		}
+	}
+	return ""
+}
+
+// typos metavlhths, parametrou h global
+func (c *CodeGenerator) symbolType(methodName, name string) string {
+	if symbol, _, exists := c.lookupLocal(methodName, name); exists {
+		return symbol.Type
+	}
+	if symbol, exists := c.globalTable.Lookup(name); exists {
+		return symbol.Type
+	}
+	return ""
+}
+
+// fortwsh timhs sto rA, o long fortwnetai sto rAX apo dyo synexomenes lekseis
+func (c *CodeGenerator) loadValue(addr, typ string) {
+	c.output.WriteString(fmt.Sprintf("        LDA   %s\n", addr))
+	if typ == "long" {
+		c.output.WriteString(fmt.Sprintf("        LDX   %s\n", wordAfter(addr)))
+	}
+}
+
+func (c *CodeGenerator) storeValue(addr, typ string) {
+	c.output.WriteString(fmt.Sprintf("        STA   %s\n", addr))
+	if typ == "long" {
+		c.output.WriteString(fmt.Sprintf("        STX   %s\n", wordAfter(addr)))
+	}
+}
+
+func (c *CodeGenerator) loadConstant(value int, typ string) {
+	if typ == "long" {
+		high, low := longWords(value)
+		c.output.WriteString(fmt.Sprintf("        LDA   =%d=\n", high))
+		c.output.WriteString(fmt.Sprintf("        LDX   =%d=\n", low))
+		return
+	}
+	c.output.WriteString(fmt.Sprintf("        LDA   =%d=\n", value))
+}
+
+// temp gia mia timh tou typou (dyo synexomena temps gia long)
+func (c *CodeGenerator) allocateValueTemp(typ string) string {
+	temp := c.allocateTemp()
+	for i := 1; i < typeWords(typ); i++ {
+		c.allocateTemp()
+	}
+	return temp
+}
+
+func (c *CodeGenerator) releaseValueTemp(typ string) {
+	for i := 0; i < typeWords(typ); i++ {
+		c.releaseTemp()
+	}
+}
+
+// symbolo kai offset sto frame, apo to eswterotero scope pros ta exwterika
+func (c *CodeGenerator) lookupLocal(methodName, name string) (*Symbol, int, bool) {
+	for scope := c.scope; scope != nil && scope != c.globalTable; scope = scope.Parent {
+		symbol, exists := scope.Symbols[name]
+		if !exists {
+			continue
+		}
+
+		if symbol.Kind == "parameter" {
+			return symbol, c.getParameterAddress(methodName, symbol.Offset), true
+		}
+		offset, found := c.addressMap[c.makeVariableName(scope.Name, name)]
+		return symbol, offset, found
+	}
+	return nil, 0, false
+}
+
+// dieythinsh metavlhths h parametrou ths methodou
+func (c *CodeGenerator) resolveAddress(methodName, name string) (string, bool) {
+	if _, offset, exists := c.lookupLocal(methodName, name); exists {
+		return c.frameAddress(offset), true
+	}
+
+	// global metavlhtes kai stathere (meta ta locals kai tis parametrous)
+	if symbol, exists := c.globalTable.Lookup(name); exists {
+		switch symbol.Kind {
+		case "global":
+			return fmt.Sprintf("%d", c.globalAddress[name]), true
+		case "const":
+			return fmt.Sprintf("=%d=", symbol.Value), true
 		}
 	}
-	return -1
+	return "", false
+}
+
+func (c *CodeGenerator) makeParameterName(methodName string, index int) string {
+	return fmt.Sprintf("%s_param_%d", methodName, index)
 }
 
+func (c *CodeGenerator) makeVariableName(methodName, varName string) string {
+	return fmt.Sprintf("%s_%s", methodName, varName)
+}
+
+// offset ths parametrou index sto frame ths methodou
 func (c *CodeGenerator) getParameterAddress(methodName string, index int) int {
 	paramName := c.makeParameterName(methodName, index)
-	if addr, exists := c.addressMap[paramName]; exists {
-		return addr
+	if offset, exists := c.addressMap[paramName]; exists {
+		return offset
 	}
-
-	addr := c.currentAddress
-	c.addressMap[paramName] = addr
-	c.currentAddress++
-	return addr
+	return index + 1
 }
@@ -5,8 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/val-makkas/mixal_compiler/mix"
 )
 
+// orio entolwn gia to run mode (gia atermones vrogxous)
+const MAX_STEPS = 10000000
+
 type Compiler struct {
 	lexer    *Lexer
 	parser   *Parser
@@ -26,9 +31,80 @@ func NewCompiler() *Compiler {
 }
 
 func (c *Compiler) Compile(sourceFile string) error {
+	mixalCode, err := c.translate(sourceFile)
+	if err != nil {
+		return err
+	}
+
+	outputFile := c.getOutputFileName(sourceFile)
+	if err := os.WriteFile(outputFile, []byte(mixalCode), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if c.verbose {
+		fmt.Printf("Output written to %s\n", outputFile)
+	}
+	return nil
+}
+
+// metaglwttizei, kanei assemble kai ektelei to programma ston simulator
+func (c *Compiler) Run(sourceFile string) (*mix.Machine, error) {
+	mixalCode, err := c.translate(sourceFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// ASSEMBLY
+	if c.verbose {
+		fmt.Println()
+		fmt.Println("Phase 5: Assembly")
+		fmt.Println("-----------------------------------------")
+	}
+
+	program, err := mix.Assemble(mixalCode)
+	if err != nil {
+		return nil, fmt.Errorf("assembly failed: %w", err)
+	}
+	if c.verbose {
+		fmt.Printf("Program starts at location %d\n\n", program.Start)
+	}
+
+	// EKTELESH
+	if c.verbose {
+		fmt.Println("Phase 6: Execution")
+		fmt.Println("-----------------------------------------")
+	}
+
+	// print/printstr ston line printer, read apo ton card reader
+	machine := mix.NewMachine()
+	machine.Attach(mix.LinePrinterUnit, mix.NewLinePrinter(os.Stdout))
+	machine.Attach(mix.CardReaderUnit, mix.NewCardReader(os.Stdin))
+	machine.Load(program.Memory, program.Start)
+	if err := machine.Run(MAX_STEPS); err != nil {
+		return machine, fmt.Errorf("execution failed: %w", err)
+	}
+	if label, code, trapped := runtimeTrap(machine, program); trapped {
+		return machine, fmt.Errorf("runtime error %s (rX = %d)", label, code)
+	}
+	return machine, nil
+}
+
+// an to programma stamathse sto stub enos runtime error (ENTX code, HLT)
+// kai oxi sto HLT ths main, epistrefei to label kai ton kwdika tou rX
+func runtimeTrap(machine *mix.Machine, program *mix.Program) (string, int64, bool) {
+	for _, rtErr := range runtimeErrors {
+		location, exists := program.Symbols[rtErr.Label]
+		if exists && machine.PC == location+2 {
+			return rtErr.Label, machine.X.Value(), true
+		}
+	}
+	return "", 0, false
+}
+
+// oi 4 fashs ths metaglwttishs, epistrefei ton kwdika MIXAL
+func (c *Compiler) translate(sourceFile string) (string, error) {
 	content, err := os.ReadFile(sourceFile)
 	if err != nil {
-		return fmt.Errorf("failed to read source file: %w", err)
+		return "", fmt.Errorf("failed to read source file: %w", err)
 	}
 	source := string(content)
 
@@ -40,7 +116,7 @@ func (c *Compiler) Compile(sourceFile string) error {
 
 	tokens, err := c.lexer.Tokenize(source)
 	if err != nil {
-		return fmt.Errorf("lexical analysis failed: %w", err)
+		return "", fmt.Errorf("lexical analysis failed: %w", err)
 	}
 
 	if c.verbose {
@@ -56,7 +132,7 @@ func (c *Compiler) Compile(sourceFile string) error {
 
 	ast, err := c.parser.Parse(tokens)
 	if err != nil {
-		return fmt.Errorf("parsing failed: %w", err)
+		return "", fmt.Errorf("parsing failed: %w", err)
 	}
 	if c.verbose {
 		fmt.Printf("Generated AST with %d methos\n\n", len(ast.Methods))
@@ -70,10 +146,13 @@ func (c *Compiler) Compile(sourceFile string) error {
 
 	symbolTables, err := c.semantic.Analyze(ast)
 	if err != nil {
-		return fmt.Errorf("semantic analysis failed: %w", err)
+		return "", fmt.Errorf("semantic analysis failed: %w", err)
 	}
 	if c.verbose {
 		fmt.Printf("Semantic analysis passed\n")
+		for _, warning := range c.semantic.Warnings() {
+			fmt.Printf("   - warning: %s\n", warning)
+		}
 		fmt.Printf("   - Found %d methods\n", len(symbolTables))
 		fmt.Printf("   - Main method: ✓\n\n")
 	}
@@ -86,20 +165,12 @@ func (c *Compiler) Compile(sourceFile string) error {
 
 	mixalCode, err := c.codegen.Generate(ast, symbolTables)
 	if err != nil {
-		return fmt.Errorf("code generation failed: %w", err)
+		return "", fmt.Errorf("code generation failed: %w", err)
 	}
 	if c.verbose {
 		fmt.Printf("Generated %d lines of MIXAL code\n", strings.Count(mixalCode, "\n")+1)
 	}
-
-	outputFile := c.getOutputFileName(sourceFile)
-	if err := os.WriteFile(outputFile, []byte(mixalCode), 0644); err != nil {
-		return fmt.Errorf("failed to write output file: %w", err)
-	}
-	if c.verbose {
-		fmt.Printf("Output written to %s\n", outputFile)
-	}
-	return nil
+	return mixalCode, nil
 }
 
 func (c *Compiler) getOutputFileName(sourceFile string) string {
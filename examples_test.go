@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/val-makkas/mixal_compiler/mix"
+)
+
+// prothemata twn sfalmatwn tou Compiler ana fash
+var phaseErrors = map[string]string{
+	"lexical":  "lexical analysis failed",
+	"parsing":  "parsing failed",
+	"semantic": "semantic analysis failed",
+	"codegen":  "code generation failed",
+}
+
+// anamenomeno apotelesma apo ta sxolia tou example:
+//
+//	// expect: <timh pou epistrefei h main>
+//	// expect-error: <fash>: <meros tou mhnymatos>
+//	// expect-trap: <etiketa runtime error, p.x. ERRDIV>
+//
+// kai proairetika, mia fora ana grammh:
+//
+//	// output: <grammh pou typwnei to programma>
+//	// input: <karta gia ton card reader>
+type expectation struct {
+	value    int64
+	hasValue bool
+	phase    string
+	message  string
+	trap     string
+	output   []string
+	input    []string
+}
+
+func TestExamples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("examples", "*", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no example files found")
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			expect := readExpectation(t, file)
+
+			machine, program, printed, err := runExample(t, file, expect.input)
+			if expect.phase != "" {
+				if err == nil {
+					t.Fatalf("expected %s error containing %q, compilation succeeded", expect.phase, expect.message)
+				}
+				if !strings.HasPrefix(err.Error(), phaseErrors[expect.phase]) {
+					t.Fatalf("expected %s error, got: %v", expect.phase, err)
+				}
+				if !strings.Contains(err.Error(), expect.message) {
+					t.Fatalf("expected error containing %q, got: %v", expect.message, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expect.output != nil {
+				lines := strings.Split(strings.TrimSuffix(printed, "\n"), "\n")
+				if printed == "" {
+					lines = nil
+				}
+				if strings.Join(lines, "\n") != strings.Join(expect.output, "\n") {
+					t.Fatalf("printed:\n%s\nexpected:\n%s", printed, strings.Join(expect.output, "\n"))
+				}
+			}
+			if expect.trap != "" {
+				checkTrap(t, machine, program, expect.trap)
+				return
+			}
+			if label, code, trapped := runtimeTrap(machine, program); trapped {
+				t.Fatalf("program stopped in %s with rX = %d", label, code)
+			}
+			if got := machine.A.Value(); got != expect.value {
+				t.Fatalf("main returned %d, expected %d", got, expect.value)
+			}
+		})
+	}
+}
+
+func readExpectation(t *testing.T, file string) expectation {
+	t.Helper()
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expect expectation
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		if value, found := strings.CutPrefix(line, "// expect:"); found {
+			expect.value, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				t.Fatalf("invalid expected value in %s: %v", file, err)
+			}
+			expect.hasValue = true
+		}
+
+		if value, found := strings.CutPrefix(line, "// expect-error:"); found {
+			phase, message, _ := strings.Cut(strings.TrimSpace(value), ":")
+			if _, exists := phaseErrors[phase]; !exists {
+				t.Fatalf("unknown phase %q in %s", phase, file)
+			}
+			expect.phase = phase
+			expect.message = strings.TrimSpace(message)
+		}
+
+		if value, found := strings.CutPrefix(line, "// expect-trap:"); found {
+			expect.trap = strings.TrimSpace(value)
+		}
+
+		if value, found := strings.CutPrefix(line, "// output:"); found {
+			expect.output = append(expect.output, strings.TrimSpace(value))
+		}
+
+		if value, found := strings.CutPrefix(line, "// input:"); found {
+			expect.input = append(expect.input, strings.TrimSpace(value))
+		}
+	}
+
+	annotations := 0
+	for _, present := range []bool{expect.hasValue, expect.phase != "", expect.trap != ""} {
+		if present {
+			annotations++
+		}
+	}
+	if annotations != 1 {
+		t.Fatalf("%s needs exactly one '// expect:', '// expect-error:' or '// expect-trap:' annotation", file)
+	}
+	return expect
+}
+
+// metaglwttish me ton Compiler se proswrino fakelo kai ektelesh ston simulator,
+// epistrefei kai oti typwthike ston line printer
+func runExample(t *testing.T, file string, input []string) (*mix.Machine, *mix.Program, string, error) {
+	t.Helper()
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceFile := filepath.Join(t.TempDir(), filepath.Base(file))
+	if err := os.WriteFile(sourceFile, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	compiler.verbose = false
+	if err := compiler.Compile(sourceFile); err != nil {
+		return nil, nil, "", err
+	}
+
+	mixalCode, err := os.ReadFile(compiler.getOutputFileName(sourceFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := mix.Assemble(string(mixalCode))
+	if err != nil {
+		t.Fatalf("assembly failed: %v", err)
+	}
+
+	var printed bytes.Buffer
+	cards := strings.Join(input, "\n")
+	machine := mix.NewMachine()
+	machine.Attach(mix.LinePrinterUnit, mix.NewLinePrinter(&printed))
+	machine.Attach(mix.CardReaderUnit, mix.NewCardReader(strings.NewReader(cards)))
+	machine.Load(program.Memory, program.Start)
+	if err := machine.Run(MAX_STEPS); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	return machine, program, printed.String(), nil
+}
+
+// to programma prepei na stamathse sto stub tou runtime error (ENTX code, HLT)
+func checkTrap(t *testing.T, machine *mix.Machine, program *mix.Program, label string) {
+	t.Helper()
+
+	if _, exists := program.Symbols[label]; !exists {
+		t.Fatalf("runtime error %s is not generated", label)
+	}
+	trap, code, trapped := runtimeTrap(machine, program)
+	if !trapped || trap != label {
+		t.Fatalf("expected halt at %s, halted at location %d with rA = %d", label, machine.PC-1, machine.A.Value())
+	}
+	for _, rtErr := range runtimeErrors {
+		if rtErr.Label == label && code != int64(rtErr.Code) {
+			t.Fatalf("expected error code %d in rX, got %d", rtErr.Code, code)
+		}
+	}
+}
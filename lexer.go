@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"unicode"
+
+	"github.com/val-makkas/mixal_compiler/mix"
 )
 
 type Lexer struct {
@@ -86,12 +89,28 @@ func (l *Lexer) nextToken() (Token, error) {
 	case '}':
 		l.advance()
 		return Token{Type: TOK_RBRACE, Value: "}", Line: startLine, Column: startColumn}, nil
+	case '[':
+		l.advance()
+		return Token{Type: TOK_LBRACKET, Value: "[", Line: startLine, Column: startColumn}, nil
+	case ']':
+		l.advance()
+		return Token{Type: TOK_RBRACKET, Value: "]", Line: startLine, Column: startColumn}, nil
 	case ',':
 		l.advance()
 		return Token{Type: TOK_COMMA, Value: ",", Line: startLine, Column: startColumn}, nil
 	case ';':
 		l.advance()
 		return Token{Type: TOK_SEMICOLON, Value: ";", Line: startLine, Column: startColumn}, nil
+	case ':':
+		l.advance()
+		return Token{Type: TOK_COLON, Value: ":", Line: startLine, Column: startColumn}, nil
+	case '.':
+		l.advance()
+		return Token{Type: TOK_DOT, Value: ".", Line: startLine, Column: startColumn}, nil
+	case '\'':
+		return l.readCharLiteral()
+	case '"':
+		return l.readStringLiteral()
 	case '+':
 		l.advance()
 		return Token{Type: TOK_PLUS, Value: "+", Line: startLine, Column: startColumn}, nil
@@ -101,6 +120,9 @@ func (l *Lexer) nextToken() (Token, error) {
 	case '/':
 		l.advance()
 		return Token{Type: TOK_DIVIDE, Value: "/", Line: startLine, Column: startColumn}, nil
+	case '%':
+		l.advance()
+		return Token{Type: TOK_MODULO, Value: "%", Line: startLine, Column: startColumn}, nil
 
 	case '-': // mporei na einai operator h sign gia arithmo
 		return l.handleMinus()
@@ -112,6 +134,10 @@ func (l *Lexer) nextToken() (Token, error) {
 		return l.handleLessThan()
 	case '!': // ! h !=
 		return l.handleExclamation()
+	case '&': // &&
+		return l.handleDouble('&', TOK_AND)
+	case '|': // ||
+		return l.handleDouble('|', TOK_OR)
 	}
 
 	if l.isLetter(ch) {
@@ -169,6 +195,13 @@ func (l *Lexer) handleLessThan() (Token, error) {
 		return Token{TOK_LE, "<=", startLine, startColumn}, nil
 	}
 
+	// h metatopish aristera
+	if l.position < len(l.input) &&
+		l.input[l.position] == '<' {
+		l.advance()
+		return Token{TOK_SHL, "<<", startLine, startColumn}, nil
+	}
+
 	//alliws einai aplo <
 	return Token{TOK_LT, "<", startLine, startColumn}, nil
 }
@@ -186,6 +219,13 @@ func (l *Lexer) handleGreaterThan() (Token, error) {
 		return Token{TOK_GE, ">=", startLine, startColumn}, nil
 	}
 
+	// h metatopish deksia
+	if l.position < len(l.input) &&
+		l.input[l.position] == '>' {
+		l.advance()
+		return Token{TOK_SHR, ">>", startLine, startColumn}, nil
+	}
+
 	//alliws einai aplo >
 	return Token{TOK_GT, ">", startLine, startColumn}, nil
 }
@@ -203,9 +243,109 @@ func (l *Lexer) handleExclamation() (Token, error) {
 		return Token{TOK_NE, "!=", startLine, startColumn}, nil
 	}
 
-	//alliws einai akyro
+	//alliws einai logiko not
+	return Token{TOK_NOT, "!", startLine, startColumn}, nil
+}
+
+// && kai || (to monadiko & h | einai akyro)
+func (l *Lexer) handleDouble(ch byte, tokenType TokenType) (Token, error) {
+	startLine := l.line
+	startColumn := l.column
+
+	l.advance()
+
+	if l.position < len(l.input) &&
+		l.input[l.position] == ch {
+		l.advance()
+		return Token{tokenType, string([]byte{ch, ch}), startLine, startColumn}, nil
+	}
+
 	return Token{TOK_ERROR, "", startLine, startColumn},
-		fmt.Errorf("unexpected character '!' at line %d, column %d (did you mean '!='?)", startLine, startColumn)
+		fmt.Errorf("unexpected character '%c' at line %d, column %d (did you mean '%c%c'?)", ch, startLine, startColumn, ch, ch)
+}
+
+// 'c', h timh tou token einai o xarakthras (sto alfavhto tou MIX)
+func (l *Lexer) readCharLiteral() (Token, error) {
+	startLine := l.line
+	startColumn := l.column
+	l.advance() // skip '\''
+
+	ch, err := l.readLiteralChar('\'')
+	if err != nil {
+		return Token{TOK_ERROR, "", startLine, startColumn}, err
+	}
+
+	if l.position >= len(l.input) || l.input[l.position] != '\'' {
+		return Token{TOK_ERROR, "", startLine, startColumn},
+			fmt.Errorf("unterminated character literal at line %d, column %d", startLine, startColumn)
+	}
+	l.advance()
+
+	return Token{TOK_CHARLIT, string(ch), startLine, startColumn}, nil
+}
+
+// "...", h timh tou token einai to keimeno meta ta escapes
+func (l *Lexer) readStringLiteral() (Token, error) {
+	startLine := l.line
+	startColumn := l.column
+	l.advance() // skip '"'
+
+	var text []byte
+	for l.position < len(l.input) && l.input[l.position] != '"' {
+		ch, err := l.readLiteralChar('"')
+		if err != nil {
+			return Token{TOK_ERROR, "", startLine, startColumn}, err
+		}
+		text = append(text, ch)
+	}
+
+	if l.position >= len(l.input) {
+		return Token{TOK_ERROR, "", startLine, startColumn},
+			fmt.Errorf("unterminated string literal at line %d, column %d", startLine, startColumn)
+	}
+	l.advance()
+
+	return Token{TOK_STRING, string(text), startLine, startColumn}, nil
+}
+
+// enas xarakthras literal me escapes: \' kai \D, \S, \P gia ta Δ, Σ, Π
+// tou MIX, prepei na yparxei ston pinaka xarakthrwn tou MIX
+func (l *Lexer) readLiteralChar(quote byte) (byte, error) {
+	line := l.line
+	column := l.column
+
+	if l.position >= len(l.input) || l.input[l.position] == '\n' || l.input[l.position] == quote {
+		return 0, fmt.Errorf("unterminated or empty literal at line %d, column %d", line, column)
+	}
+
+	ch := l.input[l.position]
+	l.advance()
+
+	if ch == '\\' {
+		if l.position >= len(l.input) {
+			return 0, fmt.Errorf("unterminated literal at line %d, column %d", line, column)
+		}
+		escape := l.input[l.position]
+		l.advance()
+
+		switch escape {
+		case '\'':
+			ch = '\''
+		case 'D':
+			ch = '~'
+		case 'S':
+			ch = '['
+		case 'P':
+			ch = '#'
+		default:
+			return 0, fmt.Errorf("unknown escape sequence '\\%c' at line %d, column %d", escape, line, column)
+		}
+	}
+
+	if mix.CharCode(rune(ch)) < 0 {
+		return 0, fmt.Errorf("character '%c' at line %d, column %d has no MIX character code", ch, line, column)
+	}
+	return ch, nil
 }
 
 // kanonas id = letter (letter | digit | '_")*
@@ -231,9 +371,38 @@ func (l *Lexer) readIdentifier() (Token, error) {
 		tokenType = keywordType
 	}
 
+	if tokenType == TOK_ASM {
+		return l.readAsmBlock(startLine, startColumn)
+	}
+
 	return Token{tokenType, value, startLine, startColumn}, nil
 }
 
+// to keimeno tou asm { ... } xwris epeksergasia mexri to '}',
+// h grammh tou token einai h grammh tou '{' (ekei arxizei to keimeno)
+func (l *Lexer) readAsmBlock(startLine, startColumn int) (Token, error) {
+	l.skipWhitespace()
+	if l.position >= len(l.input) || l.input[l.position] != '{' {
+		return Token{TOK_ERROR, "", startLine, startColumn},
+			fmt.Errorf("expected '{' after asm at line %d, column %d", startLine, startColumn)
+	}
+	bodyLine := l.line
+	l.advance() // skip '{'
+
+	start := l.position
+	for l.position < len(l.input) && l.input[l.position] != '}' {
+		l.advance()
+	}
+	if l.position >= len(l.input) {
+		return Token{TOK_ERROR, "", startLine, startColumn},
+			fmt.Errorf("unterminated asm block at line %d, column %d", startLine, startColumn)
+	}
+	body := l.input[start:l.position]
+	l.advance() // skip '}'
+
+	return Token{TOK_ASM, body, bodyLine, startColumn}, nil
+}
+
 // kanonas num = '-'? [1-9] digit*
 func (l *Lexer) readNumber() (Token, error) {
 	start := l.position
@@ -258,16 +427,34 @@ func (l *Lexer) readNumber() (Token, error) {
 		l.advance()
 	}
 
+	// dekadiko meros: '.' kai toulaxiston ena pshfio
+	tokenType := TOK_NUM
+	if l.position+1 < len(l.input) && l.input[l.position] == '.' && unicode.IsDigit(rune(l.input[l.position+1])) {
+		tokenType = TOK_FLOATNUM
+		l.advance()
+		for l.position < len(l.input) && unicode.IsDigit(rune(l.input[l.position])) {
+			l.advance()
+		}
+	}
+
 	value := l.input[start:l.position]
 
 	// elsegxoume an einai egkyros arithmos
-	matched, _ := regexp.MatchString(`^-?([1-9]\d*|0)$`, value)
+	matched, _ := regexp.MatchString(`^-?([1-9]\d*|0)(\.\d+)?$`, value)
 	if !matched {
 		return Token{TOK_ERROR, "", startLine, startColumn},
 			fmt.Errorf("invalid number format '%s' at line %d, column %d", value, startLine, startColumn)
 	}
 
-	return Token{TOK_NUM, value, startLine, startColumn}, nil
+	// o akeraios prepei na xwraei toulaxiston se long (dyo lekseis)
+	if tokenType == TOK_NUM {
+		if number, err := strconv.ParseInt(value, 10, 64); err != nil || number > MAX_LONG || number < -MAX_LONG {
+			return Token{TOK_ERROR, "", startLine, startColumn},
+				fmt.Errorf("number '%s' at line %d, column %d does not fit in a long", value, startLine, startColumn)
+		}
+	}
+
+	return Token{tokenType, value, startLine, startColumn}, nil
 }
 
 // paraleipw kena, tabs kai newline
@@ -3,15 +3,24 @@ package main
 import (
 	"fmt"
 	"os"
+
+	"github.com/val-makkas/mixal_compiler/mix"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run main.go <name>")
+		fmt.Println("Usage: go run main.go [run | assemble] <name>")
 		os.Exit(1)
 	}
 
+	// run mode: metaglwttish kai ektelesh ston simulator
+	// assemble mode: eikona mnhmhs kai symbola apo arxeio MIXAL
+	mode := ""
 	sourceFile := os.Args[1]
+	if (os.Args[1] == "run" || os.Args[1] == "assemble") && len(os.Args) > 2 {
+		mode = os.Args[1]
+		sourceFile = os.Args[2]
+	}
 
 	if _, err := os.Stat(sourceFile); os.IsNotExist(err) {
 		fmt.Printf("Error: Source file '%s' does not exist.\n", sourceFile)
@@ -20,6 +29,37 @@ func main() {
 
 	compiler := NewCompiler()
 
+	switch mode {
+	case "run":
+		fmt.Printf("Running source file: %s\n", sourceFile)
+		fmt.Println("=====================================")
+
+		machine, err := compiler.Run(sourceFile)
+		if err != nil {
+			fmt.Printf("Run failed: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Final rA: %d\n", machine.A.Value())
+		fmt.Printf("Instructions executed: %d\n", machine.Steps)
+		fmt.Println("=====================================")
+		return
+
+	case "assemble":
+		content, err := os.ReadFile(sourceFile)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			os.Exit(1)
+		}
+
+		program, err := mix.Assemble(string(content))
+		if err != nil {
+			fmt.Printf("Assembly failed: %s\n", err)
+			os.Exit(1)
+		}
+		program.WriteImage(os.Stdout)
+		return
+	}
+
 	fmt.Printf("Compiling source file: %s\n", sourceFile)
 	fmt.Println("=====================================")
 
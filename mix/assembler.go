@@ -0,0 +1,522 @@
+package mix
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// apotelesma tou assembler: eikona mnhmhs, thesh ekkinhshs kai pinakas symbolwn
+type Program struct {
+	Memory    [MemorySize]Word
+	Start     int
+	Symbols   map[string]int
+	assembled [MemorySize]bool // theseis pou graftikan apo ton assembler
+}
+
+// sfalma assembler me th grammh MIXAL pou to prokalese
+type AssemblyError struct {
+	Line    int
+	Text    string
+	Message string
+}
+
+func (e *AssemblyError) Error() string {
+	return fmt.Sprintf("assembler error at line %d: %s\n    %s", e.Line, e.Message, strings.TrimSpace(e.Text))
+}
+
+// grammh MIXAL: LOC OP ADDRESS (to ypoloipo einai sxolio)
+type sourceLine struct {
+	number  int
+	text    string
+	label   string
+	op      string
+	address string
+}
+
+type assembler struct {
+	lines     []sourceLine
+	literals  map[Word]int // timh literal -> dieythinsh sto pool
+	location  int          // location counter (*)
+	poolStart int          // arxh tou literal pool
+	poolNext  int          // epomenh thesh sto literal pool
+	program   *Program
+}
+
+// metatrepei kwdika MIXAL se eikona mnhmhs MIX
+func Assemble(source string) (*Program, error) {
+	a := &assembler{
+		literals: make(map[Word]int),
+		program:  &Program{Symbols: make(map[string]int)},
+	}
+
+	for i, text := range strings.Split(source, "\n") {
+		if line, ok := parseLine(i+1, text); ok {
+			a.lines = append(a.lines, line)
+		}
+	}
+
+	// 1o perasma: dieythinseis twn labels
+	if err := a.defineSymbols(); err != nil {
+		return nil, err
+	}
+
+	// 2o perasma: kwdikopoihsh lekswn
+	if err := a.encode(); err != nil {
+		return nil, err
+	}
+
+	return a.program, nil
+}
+
+func parseLine(number int, text string) (sourceLine, bool) {
+	text = strings.TrimRight(text, "\r")
+	if strings.TrimSpace(text) == "" || text[0] == '*' {
+		return sourceLine{}, false
+	}
+
+	line := sourceLine{number: number, text: text}
+	rest := text
+
+	// label mono an h grammh den arxizei me keno
+	if text[0] != ' ' && text[0] != '\t' {
+		line.label, rest = nextField(rest)
+	}
+	line.op, rest = nextField(rest)
+
+	// to ALF pairnei 5 xarakthres pou mporei na periexoun kena
+	rest = strings.TrimLeft(rest, " \t")
+	if line.op == "ALF" && strings.HasPrefix(rest, "\"") {
+		if end := strings.Index(rest[1:], "\""); end >= 0 {
+			line.address = rest[:end+2]
+			return line, true
+		}
+	}
+	line.address, _ = nextField(rest)
+	return line, true
+}
+
+func nextField(text string) (string, string) {
+	text = strings.TrimLeft(text, " \t")
+	end := strings.IndexAny(text, " \t")
+	if end < 0 {
+		return text, ""
+	}
+	return text[:end], text[end:]
+}
+
+func (a *assembler) defineSymbols() error {
+	a.location = 0
+	for _, line := range a.lines {
+		if line.op == "" {
+			return lineError(line, "missing operation")
+		}
+
+		if line.label != "" {
+			if !validSymbol(line.label) {
+				return lineError(line, "invalid symbol '%s'", line.label)
+			}
+			if _, exists := a.program.Symbols[line.label]; exists {
+				return lineError(line, "duplicate label '%s'", line.label)
+			}
+		}
+
+		if line.op == "EQU" {
+			value, err := a.wValue(line.address)
+			if err != nil {
+				return lineError(line, "%v", err)
+			}
+			a.program.Symbols[line.label] = int(value.Value())
+			continue
+		}
+
+		if line.label != "" {
+			a.program.Symbols[line.label] = a.location
+		}
+
+		switch line.op {
+		case "ORIG":
+			value, err := a.wValue(line.address)
+			if err != nil {
+				return lineError(line, "%v", err)
+			}
+			a.location = int(value.Value())
+		case "END":
+			// to literal pool arxizei sto telos tou programmatos
+			a.poolStart = a.location
+			a.poolNext = a.location
+			return nil
+		default:
+			a.location++
+		}
+	}
+	return fmt.Errorf("assembler error: missing END")
+}
+
+func (a *assembler) encode() error {
+	a.location = 0
+	for _, line := range a.lines {
+		var word Word
+		var err error
+
+		switch line.op {
+		case "EQU":
+			continue
+		case "ORIG":
+			value, _ := a.wValue(line.address)
+			a.location = int(value.Value())
+			continue
+		case "END":
+			start, err := a.evaluate(line.address)
+			if err != nil {
+				return lineError(line, "%v", err)
+			}
+			a.program.Start = int(start)
+			return a.checkPool(line)
+		case "CON":
+			word, err = a.wValue(line.address)
+		case "ALF":
+			word, err = alfValue(line.address)
+		default:
+			word, err = a.instruction(line)
+		}
+
+		if err != nil {
+			return lineError(line, "%v", err)
+		}
+		if err := a.emit(a.location, word); err != nil {
+			return lineError(line, "%v", err)
+		}
+		a.location++
+	}
+	return nil
+}
+
+// entolh: OP ADDRESS,I(F)
+func (a *assembler) instruction(line sourceLine) (Word, error) {
+	op, exists := opcodes[line.op]
+	if !exists {
+		return Word{}, fmt.Errorf("unknown operation '%s'", line.op)
+	}
+
+	addressPart, indexPart, fieldPart := splitAddress(line.address)
+
+	address, err := a.addressValue(addressPart)
+	if err != nil {
+		return Word{}, err
+	}
+
+	index := int64(0)
+	if indexPart != "" {
+		if index, err = a.evaluate(indexPart); err != nil {
+			return Word{}, err
+		}
+	}
+
+	field := int64(op.F)
+	if fieldPart != "" {
+		if field, err = a.evaluate(fieldPart); err != nil {
+			return Word{}, err
+		}
+	}
+
+	return EncodeInstruction(address, index, field, int64(op.C))
+}
+
+// kwdikopoihsh entolhs: ± AA I F C
+func EncodeInstruction(address, index, field, code int64) (Word, error) {
+	if address <= -indexModulus || address >= indexModulus {
+		return Word{}, fmt.Errorf("address %d does not fit in two bytes", address)
+	}
+	if index < 0 || index > 6 {
+		return Word{}, fmt.Errorf("invalid index register %d", index)
+	}
+	if field < 0 || field >= ByteSize {
+		return Word{}, fmt.Errorf("field %d does not fit in a byte", field)
+	}
+
+	magnitude := address
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	return Word{
+		Negative:  address < 0,
+		Magnitude: magnitude<<18 | index<<12 | field<<6 | code,
+	}, nil
+}
+
+// xwrizei to "A,I(F)" sta tria merh tou
+func splitAddress(address string) (string, string, string) {
+	rest := address
+	addressPart := ""
+
+	// to literal mporei na periexei ',' h '('
+	if strings.HasPrefix(rest, "=") {
+		end := strings.Index(rest[1:], "=")
+		if end >= 0 {
+			addressPart = rest[:end+2]
+			rest = rest[end+2:]
+		}
+	}
+
+	fieldPart := ""
+	if open := strings.Index(rest, "("); open >= 0 && strings.HasSuffix(rest, ")") {
+		fieldPart = rest[open+1 : len(rest)-1]
+		rest = rest[:open]
+	}
+
+	indexPart := ""
+	if comma := strings.Index(rest, ","); comma >= 0 {
+		indexPart = rest[comma+1:]
+		rest = rest[:comma]
+	}
+
+	return addressPart + rest, indexPart, fieldPart
+}
+
+// A-part: keno, ekfrash h literal =W-value=
+func (a *assembler) addressValue(text string) (int64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	if strings.HasPrefix(text, "=") && strings.HasSuffix(text, "=") && len(text) > 1 {
+		value, err := a.wValue(text[1 : len(text)-1])
+		if err != nil {
+			return 0, err
+		}
+		address, err := a.literal(value)
+		return int64(address), err
+	}
+	return a.evaluate(text)
+}
+
+// W-value: E1(F1),E2(F2),... opws sto CON
+func (a *assembler) wValue(text string) (Word, error) {
+	word := Word{}
+	for _, part := range strings.Split(text, ",") {
+		expr, fieldExpr := part, ""
+		if open := strings.Index(part, "("); open >= 0 && strings.HasSuffix(part, ")") {
+			expr, fieldExpr = part[:open], part[open+1:len(part)-1]
+		}
+
+		value, err := a.evaluate(expr)
+		if err != nil {
+			return Word{}, err
+		}
+
+		field := int64(5)
+		if fieldExpr != "" {
+			if field, err = a.evaluate(fieldExpr); err != nil {
+				return Word{}, err
+			}
+		}
+		l, r, ok := validField(int(field))
+		if !ok {
+			return Word{}, fmt.Errorf("invalid field specification %d", field)
+		}
+
+		w, _ := NewWord(value)
+		word.SetField(l, r, w)
+	}
+	return word, nil
+}
+
+// ALF "ABCDE"
+func alfValue(text string) (Word, error) {
+	if len(text) >= 2 && strings.HasPrefix(text, "\"") && strings.HasSuffix(text, "\"") {
+		text = text[1 : len(text)-1]
+	}
+	word, ok := PackChars(text)
+	if !ok {
+		return Word{}, fmt.Errorf("invalid ALF constant '%s'", text)
+	}
+	return word, nil
+}
+
+// dieythinsh tou literal sto pool (kathe timh mia fora), to pool den
+// mporei na pesei panw se lekseis pou exoun hdh graftei (p.x. meta apo ORIG)
+func (a *assembler) literal(value Word) (int, error) {
+	if address, exists := a.literals[value]; exists {
+		return address, nil
+	}
+	address := a.poolNext
+	a.poolNext++
+	a.literals[value] = address
+	if address >= 0 && address < MemorySize {
+		if a.program.assembled[address] {
+			return 0, fmt.Errorf("location %d is already assembled", address)
+		}
+		a.program.Memory[address] = value
+		a.program.assembled[address] = true
+	}
+	return address, nil
+}
+
+func (a *assembler) checkPool(line sourceLine) error {
+	if a.poolNext > MemorySize {
+		return lineError(line, "literal pool does not fit in memory")
+	}
+	return nil
+}
+
+// ekfrash MIXAL: arithmoi, symbola kai '*', me telestes + - * / // :
+// pou ypologizontai apo aristera pros ta deksia
+func (a *assembler) evaluate(expr string) (int64, error) {
+	if expr == "" {
+		return 0, fmt.Errorf("missing expression")
+	}
+
+	pos := 0
+	var result int64
+
+	// proairetiko proshmo
+	sign := int64(1)
+	if expr[0] == '+' || expr[0] == '-' {
+		if expr[0] == '-' {
+			sign = -1
+		}
+		pos++
+	}
+
+	value, next, err := a.atom(expr, pos)
+	if err != nil {
+		return 0, err
+	}
+	result = sign * value
+	pos = next
+
+	for pos < len(expr) {
+		op := string(expr[pos])
+		if strings.HasPrefix(expr[pos:], "//") {
+			op = "//"
+		}
+		pos += len(op)
+
+		value, next, err := a.atom(expr, pos)
+		if err != nil {
+			return 0, err
+		}
+		pos = next
+
+		switch op {
+		case "+":
+			result += value
+		case "-":
+			result -= value
+		case "*":
+			result *= value
+		case "/", "//":
+			if value == 0 {
+				return 0, fmt.Errorf("division by zero in '%s'", expr)
+			}
+			if op == "//" {
+				result *= wordModulus
+			}
+			result /= value
+		case ":":
+			result = 8*result + value
+		default:
+			return 0, fmt.Errorf("invalid operator '%s' in '%s'", op, expr)
+		}
+	}
+	return result, nil
+}
+
+// atomikh ekfrash sth thesh pos: arithmos, symbolo h '*'
+func (a *assembler) atom(expr string, pos int) (int64, int, error) {
+	if pos < len(expr) && expr[pos] == '*' {
+		return int64(a.location), pos + 1, nil
+	}
+
+	end := pos
+	for end < len(expr) && isSymbolChar(expr[end]) {
+		end++
+	}
+	text := expr[pos:end]
+	if text == "" {
+		return 0, 0, fmt.Errorf("invalid expression '%s'", expr)
+	}
+
+	if value, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return value, end, nil
+	}
+	if value, exists := a.program.Symbols[text]; exists {
+		return int64(value), end, nil
+	}
+	return 0, 0, fmt.Errorf("undefined symbol '%s'", text)
+}
+
+func (a *assembler) emit(location int, word Word) error {
+	if location < 0 || location >= MemorySize {
+		return fmt.Errorf("location %d outside of memory", location)
+	}
+	if location >= a.poolStart && location < a.poolNext {
+		return fmt.Errorf("location %d overlaps the literal pool", location)
+	}
+	// dyo ORIG pou grafoun sthn idia thesh, h deuterh leksh tha xalouse thn prwth
+	if a.program.assembled[location] {
+		return fmt.Errorf("location %d is already assembled", location)
+	}
+	a.program.Memory[location] = word
+	a.program.assembled[location] = true
+	return nil
+}
+
+// grafei thn eikona mnhmhs kai ton pinaka symbolwn
+func (p *Program) WriteImage(w io.Writer) {
+	fmt.Fprintln(w, "Memory image:")
+	for location, word := range p.Memory {
+		if !p.assembled[location] {
+			continue
+		}
+		sign := '+'
+		if word.Negative {
+			sign = '-'
+		}
+		fmt.Fprintf(w, "  %04d: %c %04d %02d %02d %02d  (%d)\n", location, sign,
+			word.Field(1, 2).Magnitude, word.Byte(3), word.Byte(4), word.Byte(5), word.Value())
+	}
+
+	names := make([]string, 0, len(p.Symbols))
+	for name := range p.Symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Symbol table:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %d\n", name, p.Symbols[name])
+	}
+	fmt.Fprintf(w, "Start: %d\n", p.Start)
+}
+
+// symbolo MIXAL: 1-10 grammata h pshfia me toulaxiston ena gramma
+func validSymbol(name string) bool {
+	if len(name) == 0 || len(name) > 10 {
+		return false
+	}
+	hasLetter := false
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if ch >= 'A' && ch <= 'Z' {
+			hasLetter = true
+		} else if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return hasLetter
+}
+
+func isSymbolChar(ch byte) bool {
+	return (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+func lineError(line sourceLine, format string, args ...any) error {
+	return &AssemblyError{
+		Line:    line.number,
+		Text:    line.text,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
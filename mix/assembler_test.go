@@ -0,0 +1,109 @@
+package mix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAssemble(t *testing.T) {
+	source := strings.Join([]string{
+		"N       EQU   3",
+		"        ORIG  100",
+		"START   LDA   X",
+		"        ADD   =N=",
+		"        HLT",
+		"X       CON   4",
+		"        END   START",
+	}, "\n")
+
+	program, err := Assemble(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if program.Start != 100 || program.Symbols["X"] != 103 {
+		t.Fatalf("start %d and X = %d, expected 100 and 103", program.Start, program.Symbols["X"])
+	}
+
+	// to literal =N= mpainei sto pool amesws meta to programma
+	machine := NewMachine()
+	machine.Load(program.Memory, program.Start)
+	if err := machine.Run(100); err != nil {
+		t.Fatal(err)
+	}
+	if got := machine.A.Value(); got != 7 {
+		t.Fatalf("rA = %d, expected 7", got)
+	}
+}
+
+// kathe sfalma anaferei th grammh MIXAL pou to prokalese
+func TestAssembleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []string
+		line    int
+		message string
+	}{
+		{
+			name:    "duplicate label",
+			source:  []string{"START   NOP", "START   HLT", "        END   START"},
+			line:    2,
+			message: "duplicate label 'START'",
+		},
+		{
+			name:    "undefined symbol",
+			source:  []string{"START   LDA   VALUE", "        HLT", "        END   START"},
+			line:    1,
+			message: "undefined symbol 'VALUE'",
+		},
+		{
+			name:    "invalid symbol",
+			source:  []string{"ABCDEFGHIJK NOP", "        END   ABCDEFGHIJK"},
+			line:    1,
+			message: "invalid symbol 'ABCDEFGHIJK'",
+		},
+		{
+			name:    "unknown operation",
+			source:  []string{"START   LDQ   0", "        END   START"},
+			line:    1,
+			message: "unknown operation 'LDQ'",
+		},
+		{
+			name:    "location assembled twice",
+			source:  []string{"        ORIG  100", "START   NOP", "        ORIG  100", "        HLT", "        END   START"},
+			line:    4,
+			message: "location 100 is already assembled",
+		},
+		{
+			name:    "literal pool over assembled word",
+			source:  []string{"        ORIG  11", "        CON   7", "        ORIG  10", "START   LDA   =5=", "        END   START"},
+			line:    4,
+			message: "location 11 is already assembled",
+		},
+		{
+			name:    "code over literal pool",
+			source:  []string{"        ORIG  10", "START   LDA   =5=", "        NOP", "        ORIG  11", "        END   START"},
+			line:    3,
+			message: "location 11 overlaps the literal pool",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Assemble(strings.Join(test.source, "\n"))
+			var asmErr *AssemblyError
+			if !errors.As(err, &asmErr) {
+				t.Fatalf("expected an assembler error, got %v", err)
+			}
+			if asmErr.Line != test.line || !strings.Contains(asmErr.Message, test.message) {
+				t.Fatalf("got error at line %d: %s, expected line %d: %s", asmErr.Line, asmErr.Message, test.line, test.message)
+			}
+		})
+	}
+}
+
+func TestAssembleMissingEnd(t *testing.T) {
+	if _, err := Assemble("START   HLT"); err == nil || !strings.Contains(err.Error(), "missing END") {
+		t.Fatalf("expected missing END error, got %v", err)
+	}
+}
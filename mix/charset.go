@@ -0,0 +1,28 @@
+package mix
+
+import "strings"
+
+// kwdikes xarakthrwn MIX 0-55 (opws sto GNU MDK: '~' = Δ, '[' = Σ, '#' = Π)
+const Charset = " ABCDEFGHI~JKLMNOPQR[#STUVWXYZ0123456789.,()+-*/=$<>@;:'"
+
+// kwdikos MIX enos xarakthra, -1 an den yparxei
+func CharCode(ch rune) int {
+	return strings.IndexRune(Charset, ch)
+}
+
+// pakettarei mexri 5 xarakthres se mia leksh (ALF)
+func PackChars(text string) (Word, bool) {
+	w := Word{}
+	runes := []rune(text)
+	for i := 0; i < WordBytes; i++ {
+		code := 0
+		if i < len(runes) {
+			code = CharCode(runes[i])
+			if code < 0 {
+				return Word{}, false
+			}
+		}
+		w.Magnitude = w.Magnitude*ByteSize + int64(code)
+	}
+	return w, len(runes) <= WordBytes
+}
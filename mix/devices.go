@@ -0,0 +1,148 @@
+package mix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// monades eisodou/eksodou
+const (
+	CardReaderUnit  = 16
+	LinePrinterUnit = 18
+	DeviceCount     = 21 // monades 0-20
+)
+
+// syskeuh I/O, oi metafores oloklhrwnontai amesws (pote busy)
+type Device interface {
+	BlockSize() int              // lekseis ana block
+	In(block []Word) error       // IN: apo th syskeuh sth mnhmh
+	Out(block []Word) error      // OUT: apo th mnhmh sth syskeuh
+	Control(operation int) error // IOC
+}
+
+// line printer: 24 lekseis = 120 xarakthres ana grammh
+type LinePrinter struct {
+	w io.Writer
+}
+
+func NewLinePrinter(w io.Writer) *LinePrinter {
+	return &LinePrinter{w: w}
+}
+
+func (p *LinePrinter) BlockSize() int { return 24 }
+
+func (p *LinePrinter) In(block []Word) error {
+	return fmt.Errorf("line printer cannot be read")
+}
+
+// mia grammh xwris ta kena sto telos
+func (p *LinePrinter) Out(block []Word) error {
+	var line strings.Builder
+	for _, w := range block {
+		for i := 1; i <= WordBytes; i++ {
+			line.WriteByte(charOf(w.Byte(i)))
+		}
+	}
+	_, err := fmt.Fprintln(p.w, strings.TrimRight(line.String(), " "))
+	return err
+}
+
+// IOC 0: allagh selidas
+func (p *LinePrinter) Control(operation int) error {
+	if operation != 0 {
+		return fmt.Errorf("line printer: unsupported control operation %d", operation)
+	}
+	_, err := fmt.Fprint(p.w, "\f")
+	return err
+}
+
+// card reader: 16 lekseis = 80 xarakthres ana karta (mia grammh keimenou)
+type CardReader struct {
+	scanner *bufio.Scanner
+}
+
+func NewCardReader(r io.Reader) *CardReader {
+	return &CardReader{scanner: bufio.NewScanner(r)}
+}
+
+func (c *CardReader) BlockSize() int { return 16 }
+
+func (c *CardReader) In(block []Word) error {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("card reader is empty")
+	}
+
+	card := []rune(c.scanner.Text())
+	if len(card) > len(block)*WordBytes {
+		return fmt.Errorf("card longer than %d characters", len(block)*WordBytes)
+	}
+
+	for k := range block {
+		end := min((k+1)*WordBytes, len(card))
+		text := ""
+		if k*WordBytes < end {
+			text = string(card[k*WordBytes : end])
+		}
+		w, ok := PackChars(text)
+		if !ok {
+			return fmt.Errorf("card %q contains characters outside the MIX character set", string(card))
+		}
+		block[k] = w
+	}
+	return nil
+}
+
+func (c *CardReader) Out(block []Word) error {
+	return fmt.Errorf("card reader cannot be written")
+}
+
+func (c *CardReader) Control(operation int) error {
+	return fmt.Errorf("card reader: unsupported control operation %d", operation)
+}
+
+// syndeei th syskeuh sth monada unit
+func (m *Machine) Attach(unit int, device Device) {
+	m.Devices[unit] = device
+}
+
+// JBUS, IOC, IN, OUT, JRED (to F einai h monada)
+func (m *Machine) inputOutput(c, unit, address int) error {
+	if unit >= DeviceCount || m.Devices[unit] == nil {
+		return fmt.Errorf("no device attached to unit %d", unit)
+	}
+	device := m.Devices[unit]
+
+	switch c {
+	case 34:
+		// JBUS: h syskeuh den einai pote busy
+		return nil
+	case 35:
+		return device.Control(address)
+	case 36, 37:
+		size := device.BlockSize()
+		if address < 0 || address+size > MemorySize {
+			return fmt.Errorf("block %d-%d outside of memory", address, address+size-1)
+		}
+		block := m.Memory[address : address+size]
+		if c == 36 {
+			return device.In(block)
+		}
+		return device.Out(block)
+	default:
+		// JRED: panta etoimh
+		return m.jumpTo(address, true)
+	}
+}
+
+// xarakthras enos kwdikou MIX ('?' gia tous kwdikous 56-63)
+func charOf(code int) byte {
+	if code < len(Charset) {
+		return Charset[code]
+	}
+	return '?'
+}
@@ -0,0 +1,51 @@
+package mix
+
+import "math"
+
+// arithmos kinhths upodiastolhs MIX: to byte 1 einai o ekthetis me
+// pleonasma q = 32 kai ta bytes 2-5 to klasma, ara h timh einai
+// ±0.f1f2f3f4 (vash 64) * 64^(e-32). To mhden einai h leksh 0.
+const (
+	floatExcess   = 32
+	fractionBytes = WordBytes - 1
+	fractionScale = 1 << (6 * fractionBytes) // 64^4
+)
+
+// kanonikopoihmenh leksh float gia to x (me stroggylopoihsh tou klasmatos),
+// to overflow einai true an o ekthetis den xwraei se ena byte. Oi
+// polu mikres times ginontai 0.
+func NewFloat(x float64) (Word, bool) {
+	if x == 0 || math.IsNaN(x) {
+		return Word{}, false
+	}
+	if math.IsInf(x, 0) {
+		return Word{Negative: x < 0}, true
+	}
+
+	// |x| = m * 2^k me m sto [1/2, 1), ara |x| = f * 64^p me f sto [1/64, 1)
+	m, k := math.Frexp(math.Abs(x))
+	p := int(math.Ceil(float64(k) / 6))
+	fraction := int64(math.Round(math.Ldexp(m, k-6*p) * fractionScale))
+	if fraction == fractionScale {
+		fraction /= ByteSize
+		p++
+	}
+
+	exponent := p + floatExcess
+	if exponent < 0 {
+		return Word{}, false
+	}
+	w := Word{Negative: x < 0, Magnitude: int64(exponent%ByteSize)*fractionScale + fraction}
+	return w, exponent >= ByteSize
+}
+
+// h timh ths lekshs ws float
+func (w Word) Float() float64 {
+	exponent := w.Byte(1) - floatExcess
+	fraction := float64(w.Magnitude%fractionScale) / fractionScale
+	value := fraction * math.Pow(ByteSize, float64(exponent))
+	if w.Negative {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,520 @@
+package mix
+
+import (
+	"fmt"
+	"math"
+)
+
+// katastash ths mhxanhs MIX
+type Machine struct {
+	Memory     [MemorySize]Word
+	A, X       Word
+	I          [7]Word // rI1-rI6 (to I[0] den xrhsimopoieitai)
+	J          Word
+	Overflow   bool // overflow toggle
+	Comparison int  // comparison indicator: -1 less, 0 equal, 1 greater
+	PC         int  // location ths epomenhs entolhs
+	Halted     bool
+	Steps      int                 // plhthos entolwn pou ektelesthkan
+	Devices    [DeviceCount]Device // syskeues I/O ana monada
+}
+
+func NewMachine() *Machine {
+	return &Machine{}
+}
+
+// fortwnei thn eikona mnhmhs kai mhdenizei tous registers (oi syskeues menoun)
+func (m *Machine) Load(memory [MemorySize]Word, start int) {
+	*m = Machine{Memory: memory, PC: start, Devices: m.Devices}
+}
+
+// ektelei mexri to HLT h mexri to orio entolwn
+func (m *Machine) Run(maxSteps int) error {
+	for !m.Halted {
+		if m.Steps >= maxSteps {
+			return fmt.Errorf("execution limit of %d instructions exceeded at location %d", maxSteps, m.PC)
+		}
+		if err := m.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ektelei mia entolh
+func (m *Machine) Step() error {
+	if m.PC < 0 || m.PC >= MemorySize {
+		return fmt.Errorf("program counter %d outside of memory", m.PC)
+	}
+
+	location := m.PC
+	inst := m.Memory[location]
+	index := inst.Byte(3)
+	f := inst.Byte(4)
+	c := inst.Byte(5)
+
+	if index > 6 {
+		return m.errorf(location, "invalid index register %d", index)
+	}
+
+	// effective address M = AA + rIi
+	address := int(inst.Field(0, 2).Value())
+	if index > 0 {
+		address += int(m.I[index].Value())
+	}
+
+	m.PC = location + 1
+	m.Steps++
+
+	var err error
+	switch {
+	case c == 0:
+		// NOP
+	case c >= 1 && c <= 4:
+		err = m.arithmetic(c, f, address)
+	case c == 5:
+		err = m.special(f)
+	case c == 6:
+		err = m.shift(f, address)
+	case c == 7:
+		err = m.move(f, address)
+	case c >= 8 && c <= 23:
+		err = m.load(c, f, address)
+	case c >= 24 && c <= 33:
+		err = m.store(c, f, address)
+	case c >= 34 && c <= 38:
+		err = m.inputOutput(c, f, address)
+	case c == 39:
+		err = m.jump(f, address)
+	case c >= 40 && c <= 47:
+		err = m.registerJump(c-40, f, address)
+	case c >= 48 && c <= 55:
+		err = m.addressTransfer(c-48, f, address, inst.Negative)
+	case c >= 56 && c <= 63:
+		err = m.compare(c-56, f, address)
+	default:
+		err = fmt.Errorf("unsupported instruction (C=%d, F=%d)", c, f)
+	}
+
+	if err != nil {
+		return m.errorf(location, "%v", err)
+	}
+	return nil
+}
+
+// ADD, SUB, MUL, DIV
+func (m *Machine) arithmetic(c, f, address int) error {
+	if f == 6 {
+		return m.floatArithmetic(c, address)
+	}
+
+	v, err := m.operand(address, f)
+	if err != nil {
+		return err
+	}
+
+	switch c {
+	case 1:
+		m.A = m.addWords(m.A, v)
+	case 2:
+		v.Negative = !v.Negative
+		m.A = m.addWords(m.A, v)
+	case 3:
+		// to ginomeno 10 bytes mpainei sto rAX me to idio proshmo
+		product := m.A.Magnitude * v.Magnitude
+		negative := m.A.Negative != v.Negative
+		m.A = Word{Negative: negative, Magnitude: product / wordModulus}
+		m.X = Word{Negative: negative, Magnitude: product % wordModulus}
+	case 4:
+		// to rAX diaireitai me to V, phliko sto rA kai ypoloipo sto rX
+		if v.Magnitude == 0 || m.A.Magnitude >= v.Magnitude {
+			m.Overflow = true
+			return nil
+		}
+		dividend := m.A.Magnitude*wordModulus + m.X.Magnitude
+		negative := m.A.Negative
+		m.A = Word{Negative: negative != v.Negative, Magnitude: dividend / v.Magnitude}
+		m.X = Word{Negative: negative, Magnitude: dividend % v.Magnitude}
+	}
+	return nil
+}
+
+// FADD, FSUB, FMUL, FDIV: to V einai olh h leksh, to apotelesma sto rA.
+// Ekthetis pou den xwraei h diairesh me 0 anavoun to overflow toggle.
+func (m *Machine) floatArithmetic(c, address int) error {
+	v, err := m.read(address)
+	if err != nil {
+		return err
+	}
+
+	a, b := m.A.Float(), v.Float()
+	var result float64
+	switch c {
+	case 1:
+		result = a + b
+	case 2:
+		result = a - b
+	case 3:
+		result = a * b
+	case 4:
+		if b == 0 {
+			m.Overflow = true
+			return nil
+		}
+		result = a / b
+	}
+
+	w, overflow := NewFloat(result)
+	if overflow {
+		m.Overflow = true
+	}
+	m.A = w
+	return nil
+}
+
+// NUM, CHAR, HLT, FLOT, FIX
+func (m *Machine) special(f int) error {
+	switch f {
+	case 0:
+		// NUM: ta 10 bytes tou rAX ws dekadika pshfia
+		value := int64(0)
+		for _, w := range []Word{m.A, m.X} {
+			for i := 1; i <= WordBytes; i++ {
+				value = (value*10 + int64(w.Byte(i)%10)) % wordModulus
+			}
+		}
+		m.A.Magnitude = value
+	case 1:
+		// CHAR: h timh tou rA se 10 kwdikous xarakthrwn (30-39)
+		digits := fmt.Sprintf("%010d", m.A.Magnitude)
+		var a, x int64
+		for i := 0; i < WordBytes; i++ {
+			a = a*ByteSize + int64(30+digits[i]-'0')
+			x = x*ByteSize + int64(30+digits[WordBytes+i]-'0')
+		}
+		m.A.Magnitude = a
+		m.X.Magnitude = x
+	case 2:
+		m.Halted = true
+	case 6:
+		// FLOT: o akeraios tou rA se float
+		m.A, _ = NewFloat(float64(m.A.Value()))
+	case 7:
+		// FIX: to float tou rA ston plhsiestero akeraio
+		value := math.Round(m.A.Float())
+		if math.Abs(value) >= wordModulus {
+			m.Overflow = true
+			value = math.Mod(value, wordModulus)
+		}
+		m.A, _ = NewWord(int64(value))
+	default:
+		return fmt.Errorf("unsupported special instruction (F=%d)", f)
+	}
+	return nil
+}
+
+// SLA, SRA, SLAX, SRAX, SLC, SRC (metakinhsh se bytes)
+func (m *Machine) shift(f, count int) error {
+	if count < 0 {
+		return fmt.Errorf("negative shift count %d", count)
+	}
+
+	// to rAX san 10 bytes
+	bytes := make([]int, 2*WordBytes)
+	for i := 0; i < WordBytes; i++ {
+		bytes[i] = m.A.Byte(i + 1)
+		bytes[WordBytes+i] = m.X.Byte(i + 1)
+	}
+
+	width := 2 * WordBytes
+	if f <= 1 {
+		width = WordBytes
+	}
+
+	shifted := make([]int, width)
+	for i := range shifted {
+		var src int
+		switch f {
+		case 0, 2:
+			src = i + count
+		case 1, 3:
+			src = i - count
+		case 4:
+			src = (i + count) % width
+		case 5:
+			src = ((i-count)%width + width) % width
+		default:
+			return fmt.Errorf("unsupported shift (F=%d)", f)
+		}
+		if src >= 0 && src < width {
+			shifted[i] = bytes[src]
+		}
+	}
+	copy(bytes, shifted)
+
+	m.A.Magnitude, m.X.Magnitude = 0, 0
+	for i := 0; i < WordBytes; i++ {
+		m.A.Magnitude = m.A.Magnitude*ByteSize + int64(bytes[i])
+		m.X.Magnitude = m.X.Magnitude*ByteSize + int64(bytes[WordBytes+i])
+	}
+	return nil
+}
+
+// MOVE: F lekseis apo to M sth thesh tou rI1
+func (m *Machine) move(count, address int) error {
+	for k := 0; k < count; k++ {
+		w, err := m.read(address + k)
+		if err != nil {
+			return err
+		}
+		dest := int(m.I[1].Value())
+		if dest < 0 || dest >= MemorySize {
+			return fmt.Errorf("address %d outside of memory", dest)
+		}
+		m.Memory[dest] = w
+		if err := m.setIndex(1, m.I[1].Value()+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LDA-LDX kai LDAN-LDXN
+func (m *Machine) load(c, f, address int) error {
+	v, err := m.operand(address, f)
+	if err != nil {
+		return err
+	}
+
+	reg := (c - 8) % 8
+	if c >= 16 {
+		v.Negative = !v.Negative
+	}
+	return m.setRegister(reg, v)
+}
+
+// STA-STX, STJ, STZ
+func (m *Machine) store(c, f, address int) error {
+	l, r, ok := validField(f)
+	if !ok {
+		return fmt.Errorf("invalid field specification %d", f)
+	}
+	if address < 0 || address >= MemorySize {
+		return fmt.Errorf("address %d outside of memory", address)
+	}
+
+	var src Word
+	switch {
+	case c <= 31:
+		src = *m.register(c - 24)
+	case c == 32:
+		src = m.J
+	}
+	m.Memory[address].SetField(l, r, src)
+	return nil
+}
+
+// JMP, JSJ, JOV, JNOV kai jumps me vash to comparison indicator
+func (m *Machine) jump(f, address int) error {
+	var taken bool
+	switch f {
+	case 0:
+		taken = true
+	case 1:
+		// JSJ: xwris allagh tou rJ
+		return m.jumpTo(address, false)
+	case 2:
+		taken = m.Overflow
+		m.Overflow = false
+	case 3:
+		taken = !m.Overflow
+		m.Overflow = false
+	case 4:
+		taken = m.Comparison < 0
+	case 5:
+		taken = m.Comparison == 0
+	case 6:
+		taken = m.Comparison > 0
+	case 7:
+		taken = m.Comparison >= 0
+	case 8:
+		taken = m.Comparison != 0
+	case 9:
+		taken = m.Comparison <= 0
+	default:
+		return fmt.Errorf("unsupported jump (F=%d)", f)
+	}
+
+	if taken {
+		return m.jumpTo(address, true)
+	}
+	return nil
+}
+
+// JAN-JXNP: jumps me vash thn timh tou register
+func (m *Machine) registerJump(reg, f, address int) error {
+	value := m.register(reg).Value()
+
+	var taken bool
+	switch f {
+	case 0:
+		taken = value < 0
+	case 1:
+		taken = value == 0
+	case 2:
+		taken = value > 0
+	case 3:
+		taken = value >= 0
+	case 4:
+		taken = value != 0
+	case 5:
+		taken = value <= 0
+	default:
+		return fmt.Errorf("unsupported register jump (F=%d)", f)
+	}
+
+	if taken {
+		return m.jumpTo(address, true)
+	}
+	return nil
+}
+
+// INC, DEC, ENT, ENN
+func (m *Machine) addressTransfer(reg, f, address int, negative bool) error {
+	value, _ := NewWord(int64(address))
+	if address == 0 {
+		// ENTA -0 krataei to proshmo ths entolhs
+		value.Negative = negative
+	}
+
+	switch f {
+	case 0:
+		return m.setRegister(reg, m.addWords(*m.register(reg), value))
+	case 1:
+		value.Negative = !value.Negative
+		return m.setRegister(reg, m.addWords(*m.register(reg), value))
+	case 2:
+		return m.setRegister(reg, value)
+	case 3:
+		value.Negative = !value.Negative
+		return m.setRegister(reg, value)
+	default:
+		return fmt.Errorf("unsupported address transfer (F=%d)", f)
+	}
+}
+
+// CMPA-CMPX: sygkrish tou pediou F tou register me to V,
+// FCMP (CMPA me F = 6): akrivhs sygkrish twn float (EPSILON = 0)
+func (m *Machine) compare(reg, f, address int) error {
+	if f == 6 && reg == 0 {
+		v, err := m.read(address)
+		if err != nil {
+			return err
+		}
+		m.Comparison = compareFloats(m.A.Float(), v.Float())
+		return nil
+	}
+
+	v, err := m.operand(address, f)
+	if err != nil {
+		return err
+	}
+	l, r, _ := validField(f)
+	left := m.register(reg).Field(l, r).Value()
+	right := v.Value()
+
+	switch {
+	case left < right:
+		m.Comparison = -1
+	case left > right:
+		m.Comparison = 1
+	default:
+		m.Comparison = 0
+	}
+	return nil
+}
+
+// HELPERS
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (m *Machine) register(reg int) *Word {
+	switch reg {
+	case 0:
+		return &m.A
+	case 7:
+		return &m.X
+	default:
+		return &m.I[reg]
+	}
+}
+
+// oi index registers exoun mono 2 bytes
+func (m *Machine) setRegister(reg int, value Word) error {
+	if reg >= 1 && reg <= 6 && value.Magnitude >= indexModulus {
+		return fmt.Errorf("value %d does not fit in index register rI%d", value.Value(), reg)
+	}
+	*m.register(reg) = value
+	return nil
+}
+
+func (m *Machine) setIndex(reg int, value int64) error {
+	w, _ := NewWord(value)
+	return m.setRegister(reg, w)
+}
+
+// prosthesh me overflow, to mhden krataei to proshmo tou prwtou
+func (m *Machine) addWords(a, b Word) Word {
+	sum := a.Value() + b.Value()
+	result, overflow := NewWord(sum)
+	if sum == 0 {
+		result.Negative = a.Negative
+	}
+	if overflow {
+		m.Overflow = true
+	}
+	return result
+}
+
+func (m *Machine) jumpTo(address int, saveJ bool) error {
+	if address < 0 || address >= MemorySize {
+		return fmt.Errorf("jump to address %d outside of memory", address)
+	}
+	if saveJ {
+		m.J = Word{Magnitude: int64(m.PC)}
+	}
+	m.PC = address
+	return nil
+}
+
+func (m *Machine) read(address int) (Word, error) {
+	if address < 0 || address >= MemorySize {
+		return Word{}, fmt.Errorf("address %d outside of memory", address)
+	}
+	return m.Memory[address], nil
+}
+
+// V = CONTENTS(M) sto pedio F
+func (m *Machine) operand(address, f int) (Word, error) {
+	l, r, ok := validField(f)
+	if !ok {
+		return Word{}, fmt.Errorf("invalid field specification %d", f)
+	}
+	w, err := m.read(address)
+	if err != nil {
+		return Word{}, err
+	}
+	return w.Field(l, r), nil
+}
+
+func (m *Machine) errorf(location int, format string, args ...any) error {
+	return fmt.Errorf("runtime error at location %d: %s", location, fmt.Sprintf(format, args...))
+}
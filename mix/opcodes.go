@@ -0,0 +1,80 @@
+package mix
+
+// kwdikos entolhs (C) kai default field (F)
+type opcode struct {
+	C int
+	F int
+}
+
+var opcodes = map[string]opcode{
+	"NOP":  {0, 0},
+	"ADD":  {1, 5},
+	"SUB":  {2, 5},
+	"MUL":  {3, 5},
+	"DIV":  {4, 5},
+	"FADD": {1, 6},
+	"FSUB": {2, 6},
+	"FMUL": {3, 6},
+	"FDIV": {4, 6},
+	"NUM":  {5, 0},
+	"CHAR": {5, 1},
+	"HLT":  {5, 2},
+	"FLOT": {5, 6},
+	"FIX":  {5, 7},
+	"SLA":  {6, 0},
+	"SRA":  {6, 1},
+	"SLAX": {6, 2},
+	"SRAX": {6, 3},
+	"SLC":  {6, 4},
+	"SRC":  {6, 5},
+	"MOVE": {7, 1},
+	"STJ":  {32, 2},
+	"STZ":  {33, 5},
+	"JBUS": {34, 0},
+	"IOC":  {35, 0},
+	"IN":   {36, 0},
+	"OUT":  {37, 0},
+	"JRED": {38, 0},
+	"JMP":  {39, 0},
+	"JSJ":  {39, 1},
+	"JOV":  {39, 2},
+	"JNOV": {39, 3},
+	"JL":   {39, 4},
+	"JE":   {39, 5},
+	"JG":   {39, 6},
+	"JGE":  {39, 7},
+	"JNE":  {39, 8},
+	"JLE":  {39, 9},
+	"FCMP": {56, 6},
+}
+
+// registers me th seira tou kwdikou: A, I1-I6, X
+// true an to name einai entolh tou MIX (oxi pseudo-entolh tou MIXAL)
+func IsOperation(name string) bool {
+	_, exists := opcodes[name]
+	return exists
+}
+
+var registerNames = []string{"A", "1", "2", "3", "4", "5", "6", "X"}
+
+// oi entoles pou yparxoun gia kathe register (LDA, LD1, ..., CMPX)
+func init() {
+	for i, reg := range registerNames {
+		opcodes["LD"+reg] = opcode{8 + i, 5}
+		opcodes["LD"+reg+"N"] = opcode{16 + i, 5}
+		opcodes["ST"+reg] = opcode{24 + i, 5}
+		opcodes["CMP"+reg] = opcode{56 + i, 5}
+
+		opcodes["J"+reg+"N"] = opcode{40 + i, 0}
+		opcodes["J"+reg+"Z"] = opcode{40 + i, 1}
+		opcodes["J"+reg+"P"] = opcode{40 + i, 2}
+		opcodes["J"+reg+"NN"] = opcode{40 + i, 3}
+		opcodes["J"+reg+"NZ"] = opcode{40 + i, 4}
+		opcodes["J"+reg+"NP"] = opcode{40 + i, 5}
+
+		opcodes["INC"+reg] = opcode{48 + i, 0}
+		opcodes["DEC"+reg] = opcode{48 + i, 1}
+		opcodes["ENT"+reg] = opcode{48 + i, 2}
+		opcodes["ENN"+reg] = opcode{48 + i, 3}
+	}
+}
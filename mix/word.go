@@ -0,0 +1,76 @@
+package mix
+
+const (
+	MemorySize = 4000 // lekseis mnhmhs
+	ByteSize   = 64   // binary MIX: 6 bits ana byte
+	WordBytes  = 5    // bytes ana leksh (xwris to proshmo)
+
+	wordModulus  = 1 << 30 // ByteSize^5
+	indexModulus = 1 << 12 // ByteSize^2, gia rI1-rI6 kai rJ
+)
+
+// leksh MIX: proshmo kai 5 bytes. Kratame to proshmo ksexwrista wste
+// na yparxei kai to -0.
+type Word struct {
+	Negative  bool
+	Magnitude int64 // 0 .. ByteSize^5-1
+}
+
+// dhmiourgei leksh apo akeraio, to overflow einai true an den xwraei
+func NewWord(value int64) (Word, bool) {
+	w := Word{Negative: value < 0}
+	if value < 0 {
+		value = -value
+	}
+	w.Magnitude = value % wordModulus
+	return w, value >= wordModulus
+}
+
+// akeraia timh ths lekshs
+func (w Word) Value() int64 {
+	if w.Negative {
+		return -w.Magnitude
+	}
+	return w.Magnitude
+}
+
+// byte i (1..5) ths lekshs
+func (w Word) Byte(i int) int {
+	return int(w.Magnitude>>(6*(WordBytes-i))) & (ByteSize - 1)
+}
+
+// to pedio (L:R) ths lekshs metakinhmeno deksia, opws to fortwnoun ta LD*
+func (w Word) Field(l, r int) Word {
+	result := Word{}
+	if l == 0 {
+		result.Negative = w.Negative
+		l = 1
+	}
+	if r < l {
+		return result
+	}
+	count := r - l + 1
+	result.Magnitude = (w.Magnitude >> (6 * (WordBytes - r))) & (1<<(6*count) - 1)
+	return result
+}
+
+// grafei ta deksiotera bytes tou src sto pedio (L:R), opws ta ST*
+func (w *Word) SetField(l, r int, src Word) {
+	if l == 0 {
+		w.Negative = src.Negative
+		l = 1
+	}
+	if r < l {
+		return
+	}
+	count := r - l + 1
+	shift := 6 * (WordBytes - r)
+	mask := int64(1<<(6*count)-1) << shift
+	w.Magnitude = (w.Magnitude &^ mask) | ((src.Magnitude << shift) & mask)
+}
+
+// elegxos gia egkyro field specification 8L+R
+func validField(f int) (int, int, bool) {
+	l, r := f/8, f%8
+	return l, r, l <= r && r <= WordBytes
+}
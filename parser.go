@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Recursive Descent Parser
@@ -26,8 +28,8 @@ func (p *Parser) Parse(tokens []Token) (*AST, error) {
 		return nil, fmt.Errorf("no tokens to parse")
 	}
 
-	// Parsing ksekina apo PROGRAM -> METH-LIST | e
-	methods, err := p.parseProgram()
+	// Parsing ksekina apo PROGRAM -> (GLOBAL | METH)*
+	ast, err := p.parseProgram()
 	if err != nil {
 		return nil, err
 	}
@@ -36,40 +38,103 @@ func (p *Parser) Parse(tokens []Token) (*AST, error) {
 		return nil, p.error("unexpected token after end of program")
 	}
 
-	return &AST{Methods: methods}, nil
+	return ast, nil
 }
 
-// PROGRAM -> METH-LIST | e
-func (p *Parser) parseProgram() ([]Method, error) {
-	var methods []Method
+// PROGRAM -> (STRUCT | GLOBAL | METH)*
+func (p *Parser) parseProgram() (*AST, error) {
+	ast := &AST{}
 
 	// An oxi tokens h EOF return keno
 	if p.isAtEnd() || p.current.Type == TOK_EOF {
-		return methods, nil
+		return ast, nil
 	}
 
 	// alliws synexizoume me thn anazhthsh
-	// METH-LIST -> METH METH-LIST | METH
-	for !p.isAtEnd() && p.current.Type == TOK_INT {
-		method, err := p.parseMethod()
+	for !p.isAtEnd() && (p.isType() || p.current.Type == TOK_CONST || p.current.Type == TOK_VOID) {
+		// STRUCT an meta to struct id akolouthei '{'
+		if p.current.Type == TOK_STRUCT && p.peek(2).Type == TOK_LBRACE {
+			structDecl, err := p.parseStruct()
+			if err != nil {
+				return nil, err
+			}
+			ast.Structs = append(ast.Structs, structDecl)
+			continue
+		}
+
+		// METH an meta to TYPE id akolouthei '(' (to void einai mono gia methodous)
+		if p.current.Type == TOK_VOID || (p.isType() && p.peek(p.typeLength()+1).Type == TOK_LPAREN) {
+			method, err := p.parseMethod()
+			if err != nil {
+				return nil, err
+			}
+			ast.Methods = append(ast.Methods, method)
+			continue
+		}
+
+		// GLOBAL
+		decl, err := p.parseDeclaration()
 		if err != nil {
 			return nil, err
 		}
-		methods = append(methods, method)
+		ast.Globals = append(ast.Globals, decl)
 	}
-	return methods, nil
+	return ast, nil
+}
+
+// STRUCT -> struct id '{' DECLS '}' [';']
+func (p *Parser) parseStruct() (StructDeclaration, error) {
+	startLine := p.current.Line
+	p.advance() // skip 'struct'
+
+	if p.current.Type != TOK_ID {
+		return StructDeclaration{}, p.error(fmt.Sprintf("expected struct name, got '%s'", p.current.Value))
+	}
+	name := p.current.Value
+	p.advance()
+	p.advance() // skip '{'
+
+	// ta melh einai dhlwseis xwris arxikes times (elegxontai sth semantic analysh)
+	members, err := p.parseDeclarations()
+	if err != nil {
+		return StructDeclaration{}, err
+	}
+
+	if p.current.Type != TOK_RBRACE {
+		return StructDeclaration{}, p.error(fmt.Sprintf("expected '}' after members of struct '%s', got '%s'", name, p.current.Value))
+	}
+	p.advance()
+
+	// to ';' meta to '}' einai proairetiko
+	if p.current.Type == TOK_SEMICOLON {
+		p.advance()
+	}
+
+	return StructDeclaration{
+		Name:    name,
+		Members: members,
+		Line:    startLine,
+	}, nil
 }
 
-// METH -> TYPE id '(' PARAMS ')' BODY
+// METH -> TYPE id '(' PARAMS ')' BODY | void id '(' PARAMS ')' BODY
 func (p *Parser) parseMethod() (Method, error) {
 	startLine := p.current.Line
 
-	// TYPE prepei na einai int
-	if p.current.Type != TOK_INT {
-		return Method{}, p.error(fmt.Sprintf("expected type 'int', got '%s'", p.current.Value))
+	// TYPE h void
+	if !p.isType() && p.current.Type != TOK_VOID {
+		return Method{}, p.error(fmt.Sprintf("expected return type, got '%s'", p.current.Value))
 	}
 	returnType := p.current.Value
-	p.advance()
+	if p.current.Type == TOK_VOID {
+		p.advance()
+	} else {
+		typ, err := p.parseType()
+		if err != nil {
+			return Method{}, err
+		}
+		returnType = typ
+	}
 
 	// id
 	if p.current.Type != TOK_ID {
@@ -111,7 +176,7 @@ func (p *Parser) parseMethod() (Method, error) {
 }
 
 // PARAMS -> FORMMALS | e
-// FORMALS -> TYPE id (',' TYPE id)*
+// FORMALS -> FORMAL (',' FORMAL)*
 func (p *Parser) parseParameters() ([]Parameter, error) {
 	var parameters []Parameter
 
@@ -140,16 +205,25 @@ func (p *Parser) parseParameters() ([]Parameter, error) {
 	return parameters, nil
 }
 
-// TYPE id
+// FORMAL -> TYPE id | ref TYPE id
 func (p *Parser) parseParameter() (Parameter, error) {
 	startLine := p.current.Line
 
+	// ref
+	isRef := false
+	if p.current.Type == TOK_REF {
+		isRef = true
+		p.advance()
+	}
+
 	//TYPE
-	if p.current.Type != TOK_INT {
-		return Parameter{}, p.error(fmt.Sprintf("exprected int, got '%s'", p.current.Value))
+	if !p.isType() {
+		return Parameter{}, p.error(fmt.Sprintf("expected parameter type, got '%s'", p.current.Value))
+	}
+	paramType, err := p.parseType()
+	if err != nil {
+		return Parameter{}, err
 	}
-	paramType := p.current.Value
-	p.advance()
 
 	// id
 	if p.current.Type != TOK_ID {
@@ -161,6 +235,7 @@ func (p *Parser) parseParameter() (Parameter, error) {
 	return Parameter{
 		Type: paramType,
 		Name: paramName,
+		Ref:  isRef,
 		Line: startLine,
 	}, nil
 }
@@ -201,8 +276,8 @@ func (p *Parser) parseBody() (Block, error) {
 func (p *Parser) parseDeclarations() ([]Declaration, error) {
 	var declarations []Declaration
 
-	// oso exw int decls
-	for p.current.Type == TOK_INT {
+	// oso exw decls (arxizoun me TYPE)
+	for p.isType() {
 		decl, err := p.parseDeclaration()
 		if err != nil {
 			return nil, err
@@ -212,16 +287,25 @@ func (p *Parser) parseDeclarations() ([]Declaration, error) {
 	return declarations, nil
 }
 
-// DECL -> TYPE id VARS ';'
+// DECL -> TYPE id VARS ';' | const TYPE id VARS ';'
 func (p *Parser) parseDeclaration() (Declaration, error) {
 	startLine := p.current.Line
 
+	// const
+	isConst := false
+	if p.current.Type == TOK_CONST {
+		isConst = true
+		p.advance()
+	}
+
 	// TYPE
-	if p.current.Type != TOK_INT {
-		return Declaration{}, p.error(fmt.Sprintf("expected type 'int', got '%s'", p.current.Value))
+	if !p.isType() {
+		return Declaration{}, p.error(fmt.Sprintf("expected type, got '%s'", p.current.Value))
+	}
+	varType, err := p.parseType()
+	if err != nil {
+		return Declaration{}, err
 	}
-	varType := p.current.Value
-	p.advance()
 
 	// prwth metablhth
 	var variables []Variable
@@ -249,12 +333,13 @@ func (p *Parser) parseDeclaration() (Declaration, error) {
 
 	return Declaration{
 		Type:      varType,
+		Const:     isConst,
 		Variables: variables,
 		Line:      startLine,
 	}, nil
 }
 
-// id | id '=' EXPR
+// id | id '=' EXPR | id '[' EXPR ']'
 func (p *Parser) parseVariable() (Variable, error) {
 	// id
 	if p.current.Type != TOK_ID {
@@ -263,6 +348,38 @@ func (p *Parser) parseVariable() (Variable, error) {
 	varName := p.current.Value
 	p.advance()
 
+	// pinakas: '[' EXPR ']', to megethos (statherh ekfrash) to ypologizei h semantic analysh
+	if p.current.Type == TOK_LBRACKET {
+		p.advance() // skip '['
+
+		size, err := p.parseExpression()
+		if err != nil {
+			return Variable{}, err
+		}
+
+		if p.current.Type != TOK_RBRACKET {
+			return Variable{}, p.error(fmt.Sprintf("expected ']', got '%s'", p.current.Value))
+		}
+		p.advance()
+
+		// mono keimeno mporei na arxikopoihsei pinaka
+		var initialValue Expression
+		if p.current.Type == TOK_ASSIGN {
+			p.advance() // skip '='
+			if p.current.Type != TOK_STRING {
+				return Variable{}, p.error(fmt.Sprintf("array '%s' can only be initialized with a string, got '%s'", varName, p.current.Value))
+			}
+			initialValue = &StringLiteral{Value: p.current.Value, Line: p.current.Line}
+			p.advance()
+		}
+
+		return Variable{
+			Name:         varName,
+			SizeExpr:     size,
+			InitialValue: initialValue,
+		}, nil
+	}
+
 	var initialValue Expression
 
 	// elegxw an exei timh
@@ -302,9 +419,12 @@ func (p *Parser) parseStatements() ([]Statement, error) {
 	return statements, nil
 }
 
-// STMT -> ASSIGN ';' | return EXPR ';' | if '(' EXPR ')' STMT else STMT
+// STMT -> ASSIGN ';' | CALL ';' | return EXPR ';' | return ';'
 //
-//	| while '(' EXPR ')' STMT | break ';' | BLOCK | ';'
+//	| if '(' EXPR ')' STMT else STMT | while '(' EXPR ')' STMT
+//	| for '(' SIMPLE ';' EXPR ';' SIMPLE ')' STMT | do STMT while '(' EXPR ')' ';'
+//	| switch '(' EXPR ')' '{' CASE* '}' | break [id] ';' | continue [id] ';'
+//	| id ':' STMT | BLOCK | asm '{' MIXAL '}' | ';'
 
 func (p *Parser) parseStatement() (Statement, error) {
 	switch p.current.Type {
@@ -314,25 +434,47 @@ func (p *Parser) parseStatement() (Statement, error) {
 		return p.parseIfStatement()
 	case TOK_WHILE:
 		return p.parseWhileStatement()
+	case TOK_FOR:
+		return p.parseForStatement()
+	case TOK_DO:
+		return p.parseDoWhileStatement()
+	case TOK_SWITCH:
+		return p.parseSwitchStatement()
 	case TOK_BREAK:
 		return p.parseBreakStatement()
+	case TOK_CONTINUE:
+		return p.parseContinueStatement()
 	case TOK_LBRACE:
 		return p.parseBlockStatement()
+	case TOK_ASM:
+		return p.parseAsmStatement(), nil
 	case TOK_SEMICOLON:
 		p.advance() // skip ';'
 		return nil, nil
 	case TOK_ID:
+		if p.peek(1).Type == TOK_COLON {
+			return p.parseLabeledStatement()
+		}
+		if p.peek(1).Type == TOK_LPAREN {
+			return p.parseCallStatement()
+		}
 		return p.parseAssignmentStatement()
 	default:
 		return nil, p.error(fmt.Sprintf("unexpected token '%s' at line %d", p.current.Value, p.current.Line))
 	}
 }
 
-// return EXPR ';'
+// return EXPR ';' | return ';'
 func (p *Parser) parseReturnStatement() (Statement, error) {
 	startLine := p.current.Line
 	p.advance() // skip 'return'
 
+	// return xwris timh (void methodos)
+	if p.current.Type == TOK_SEMICOLON {
+		p.advance()
+		return &ReturnStatement{Line: startLine}, nil
+	}
+
 	expr, err := p.parseExpression()
 	if err != nil {
 		return nil, err
@@ -349,6 +491,56 @@ func (p *Parser) parseReturnStatement() (Statement, error) {
 	}, nil
 }
 
+// asm '{' MIXAL '}', o lexer dinei olo to keimeno tou block se ena token
+func (p *Parser) parseAsmStatement() Statement {
+	stmt := &AsmStatement{Line: p.current.Line}
+	for i, text := range strings.Split(p.current.Value, "\n") {
+		if text = strings.TrimSpace(text); text != "" {
+			stmt.Lines = append(stmt.Lines, AsmLine{Text: text, Line: p.current.Line + i})
+		}
+	}
+	p.advance()
+	return stmt
+}
+
+// CALL ';'
+func (p *Parser) parseCallStatement() (Statement, error) {
+	stmt, err := p.parseCall()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after method call, got '%s'", p.current.Value))
+	}
+	p.advance() // skip ';'
+
+	return stmt, nil
+}
+
+// CALL -> id '(' ACTUALS ')'
+func (p *Parser) parseCall() (Statement, error) {
+	startLine := p.current.Line
+
+	expr, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExpressionStatement{
+		Expression: expr,
+		Line:       startLine,
+	}, nil
+}
+
+// SIMPLE -> LOCATION '=' EXPR | CALL (xwris ';', gia to for)
+func (p *Parser) parseSimpleStatement() (Statement, error) {
+	if p.current.Type == TOK_ID && p.peek(1).Type == TOK_LPAREN {
+		return p.parseCall()
+	}
+	return p.parseAssignment()
+}
+
 // if '(' EXPR ')' STMT else STMT
 func (p *Parser) parseIfStatement() (Statement, error) {
 	startLine := p.current.Line
@@ -434,24 +626,275 @@ func (p *Parser) parseWhileStatement() (Statement, error) {
 	}, nil
 }
 
-// break ';'
+// for '(' SIMPLE ';' EXPR ';' SIMPLE ')' STMT, ola ta merh einai proairetika
+func (p *Parser) parseForStatement() (Statement, error) {
+	startLine := p.current.Line
+	p.advance()
+
+	// '('
+	if p.current.Type != TOK_LPAREN {
+		return nil, p.error(fmt.Sprintf("expected '(' after for, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// SIMPLE ';'
+	var init Statement
+	if p.current.Type != TOK_SEMICOLON {
+		stmt, err := p.parseSimpleStatement()
+		if err != nil {
+			return nil, err
+		}
+		init = stmt
+	}
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after for initialization, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// EXPR ';'
+	var condition Expression
+	if p.current.Type != TOK_SEMICOLON {
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		condition = expr
+	}
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after for condition, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// SIMPLE ')'
+	var step Statement
+	if p.current.Type != TOK_RPAREN {
+		stmt, err := p.parseSimpleStatement()
+		if err != nil {
+			return nil, err
+		}
+		step = stmt
+	}
+	if p.current.Type != TOK_RPAREN {
+		return nil, p.error(fmt.Sprintf("expected ')', got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// STMT
+	body, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForStatement{
+		Init:      init,
+		Condition: condition,
+		Step:      step,
+		Body:      body,
+		Line:      startLine,
+	}, nil
+}
+
+// do STMT while '(' EXPR ')' ';'
+func (p *Parser) parseDoWhileStatement() (Statement, error) {
+	startLine := p.current.Line
+	p.advance()
+
+	// STMT
+	body, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	// while '('
+	if p.current.Type != TOK_WHILE {
+		return nil, p.error(fmt.Sprintf("expected 'while' after do body, got '%s'", p.current.Value))
+	}
+	p.advance()
+	if p.current.Type != TOK_LPAREN {
+		return nil, p.error(fmt.Sprintf("expected '(', got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// EXPR
+	condition, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	// ')' ';'
+	if p.current.Type != TOK_RPAREN {
+		return nil, p.error(fmt.Sprintf("expected ')', got '%s'", p.current.Value))
+	}
+	p.advance()
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after do-while, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	return &DoWhileStatement{
+		Body:      body,
+		Condition: condition,
+		Line:      startLine,
+	}, nil
+}
+
+// switch '(' EXPR ')' '{' CASE* '}'
+func (p *Parser) parseSwitchStatement() (Statement, error) {
+	startLine := p.current.Line
+	p.advance()
+
+	// '(' EXPR ')'
+	if p.current.Type != TOK_LPAREN {
+		return nil, p.error(fmt.Sprintf("expected '(' after switch, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current.Type != TOK_RPAREN {
+		return nil, p.error(fmt.Sprintf("expected ')', got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// '{'
+	if p.current.Type != TOK_LBRACE {
+		return nil, p.error(fmt.Sprintf("expected '{' after switch, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// CASE*
+	var cases []SwitchCase
+	for p.current.Type != TOK_RBRACE {
+		switchCase, err := p.parseSwitchCase()
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, switchCase)
+	}
+	p.advance() // skip '}'
+
+	return &SwitchStatement{
+		Expression: expr,
+		Cases:      cases,
+		Line:       startLine,
+	}, nil
+}
+
+// CASE -> case EXPR ':' STMTS | default ':' STMTS
+func (p *Parser) parseSwitchCase() (SwitchCase, error) {
+	startLine := p.current.Line
+
+	var value Expression
+	switch p.current.Type {
+	case TOK_CASE:
+		p.advance()
+		expr, err := p.parseExpression()
+		if err != nil {
+			return SwitchCase{}, err
+		}
+		value = expr
+	case TOK_DEFAULT:
+		p.advance()
+	default:
+		return SwitchCase{}, p.error(fmt.Sprintf("expected 'case', 'default' or '}', got '%s'", p.current.Value))
+	}
+
+	// ':'
+	if p.current.Type != TOK_COLON {
+		return SwitchCase{}, p.error(fmt.Sprintf("expected ':' after case label, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	// STMTS mexri to epomeno case h to '}'
+	var statements []Statement
+	for !p.isAtEnd() && p.current.Type != TOK_CASE && p.current.Type != TOK_DEFAULT && p.current.Type != TOK_RBRACE {
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return SwitchCase{}, err
+		}
+		if stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+
+	return SwitchCase{
+		Value:      value,
+		Statements: statements,
+		Line:       startLine,
+	}, nil
+}
+
+// continue [id] ';'
+func (p *Parser) parseContinueStatement() (Statement, error) {
+	startLine := p.current.Line
+	p.advance()
+
+	label := ""
+	if p.current.Type == TOK_ID {
+		label = p.current.Value
+		p.advance()
+	}
+
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after continue, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	return &ContinueStatement{Label: label, Line: startLine}, nil
+}
+
+// id ':' STMT
+func (p *Parser) parseLabeledStatement() (Statement, error) {
+	startLine := p.current.Line
+	label := p.current.Value
+	p.advance() // skip id
+	p.advance() // skip ':'
+
+	stmt, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabeledStatement{
+		Label:     label,
+		Statement: stmt,
+		Line:      startLine,
+	}, nil
+}
+
+// break [id] ';'
 func (p *Parser) parseBreakStatement() (Statement, error) {
 	startLine := p.current.Line
 	p.advance()
 
+	label := ""
+	if p.current.Type == TOK_ID {
+		label = p.current.Value
+		p.advance()
+	}
+
 	if p.current.Type != TOK_SEMICOLON {
 		return nil, p.error(fmt.Sprintf("expected ';' after break, got '%s'", p.current.Value))
 	}
 	p.advance()
 
-	return &BreakStatement{Line: startLine}, nil
+	return &BreakStatement{Label: label, Line: startLine}, nil
 }
 
-// '{' STMTS '}'
+// '{' DECLS STMTS '}'
 func (p *Parser) parseBlockStatement() (Statement, error) {
 	startLine := p.current.Line
 	p.advance() // skip '{'
 
+	// DECLS, orates mono mesa sto block
+	declarations, err := p.parseDeclarations()
+	if err != nil {
+		return nil, err
+	}
+
 	// STMTS
 	statements, err := p.parseStatements()
 	if err != nil {
@@ -466,7 +909,7 @@ func (p *Parser) parseBlockStatement() (Statement, error) {
 
 	return &BlockStatement{
 		Block: Block{
-			Declarations: []Declaration{}, // ta blocks den exoun declarations
+			Declarations: declarations,
 			Statements:   statements,
 		},
 		Line: startLine,
@@ -475,6 +918,23 @@ func (p *Parser) parseBlockStatement() (Statement, error) {
 
 // ASSIGN -> LOCATION '=' EXPR ';'
 func (p *Parser) parseAssignmentStatement() (Statement, error) {
+	stmt, err := p.parseAssignment()
+	if err != nil {
+		return nil, err
+	}
+
+	// ';'
+	if p.current.Type != TOK_SEMICOLON {
+		return nil, p.error(fmt.Sprintf("expected ';' after assignment, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	return stmt, nil
+}
+
+// LOCATION '=' EXPR
+// LOCATION -> id | id '[' EXPR ']' | id '.' id | LOCATION FIELD
+func (p *Parser) parseAssignment() (Statement, error) {
 	startLine := p.current.Line
 
 	// LOCATION
@@ -484,6 +944,32 @@ func (p *Parser) parseAssignmentStatement() (Statement, error) {
 	varName := p.current.Value
 	p.advance()
 
+	// stoixeio pinaka
+	var index Expression
+	if p.current.Type == TOK_LBRACKET {
+		expr, err := p.parseIndex()
+		if err != nil {
+			return nil, err
+		}
+		index = expr
+	}
+
+	// melos struct
+	member := ""
+	if index == nil && p.current.Type == TOK_DOT && p.peek(1).Type == TOK_ID {
+		member = p.parseMember()
+	}
+
+	// pedio ths lekshs
+	var field *Field
+	if p.current.Type == TOK_DOT {
+		spec, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		field = &spec
+	}
+
 	// '='
 	if p.current.Type != TOK_ASSIGN {
 		return nil, p.error(fmt.Sprintf("expected '=', got '%s'", p.current.Value))
@@ -496,28 +982,133 @@ func (p *Parser) parseAssignmentStatement() (Statement, error) {
 		return nil, err
 	}
 
-	// ';'
-	if p.current.Type != TOK_SEMICOLON {
-		return nil, p.error(fmt.Sprintf("expected ';' after assignment, got '%s'", p.current.Value))
-	}
-	p.advance()
-
 	return &Assignment{
 		Variable:   varName,
+		Index:      index,
+		Member:     member,
+		Field:      field,
 		Expression: expr,
 		Line:       startLine,
 	}, nil
 }
 
-// EXPR -> ADD-EXPR RELOP ADD-EXPR | ADD-EXPR
+// '.' id, to onoma tou melous
+func (p *Parser) parseMember() string {
+	p.advance() // skip '.'
+	member := p.current.Value
+	p.advance()
+	return member
+}
+
+// FIELD -> '.' '(' num ':' num ')', ta oria elegxontai sth semantic analysh
+func (p *Parser) parseField() (Field, error) {
+	p.advance() // skip '.'
+
+	if p.current.Type != TOK_LPAREN {
+		return Field{}, p.error(fmt.Sprintf("expected '(' after '.', got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	left, err := p.parseFieldBound()
+	if err != nil {
+		return Field{}, err
+	}
+
+	if p.current.Type != TOK_COLON {
+		return Field{}, p.error(fmt.Sprintf("expected ':' in field specification, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	right, err := p.parseFieldBound()
+	if err != nil {
+		return Field{}, err
+	}
+
+	if p.current.Type != TOK_RPAREN {
+		return Field{}, p.error(fmt.Sprintf("expected ')' after field specification, got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	return Field{Left: left, Right: right}, nil
+}
+
+func (p *Parser) parseFieldBound() (int, error) {
+	if p.current.Type != TOK_NUM {
+		return 0, p.error(fmt.Sprintf("expected field bound, got '%s'", p.current.Value))
+	}
+	bound, err := strconv.Atoi(p.current.Value)
+	if err != nil {
+		return 0, p.error(fmt.Sprintf("invalid field bound '%s'", p.current.Value))
+	}
+	p.advance()
+	return bound, nil
+}
+
+// EXPR -> OR-EXPR
 func (p *Parser) parseExpression() (Expression, error) {
-	return p.parseRelationalExpression()
+	return p.parseOrExpression()
+}
+
+// OR-EXPR -> OR-EXPR '||' AND-EXPR | AND-EXPR
+func (p *Parser) parseOrExpression() (Expression, error) {
+	left, err := p.parseAndExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	// a || b || c = (a || b) || c
+	for p.current.Type == TOK_OR {
+		operator := p.current.Value
+		line := p.current.Line
+		p.advance() // skip '||'
+
+		right, err := p.parseAndExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{
+			Left:     left,
+			Operator: operator,
+			Right:    right,
+			Line:     line,
+		}
+	}
+	return left, nil
+}
+
+// AND-EXPR -> AND-EXPR '&&' REL-EXPR | REL-EXPR
+func (p *Parser) parseAndExpression() (Expression, error) {
+	left, err := p.parseRelationalExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	// a && b && c = (a && b) && c
+	for p.current.Type == TOK_AND {
+		operator := p.current.Value
+		line := p.current.Line
+		p.advance() // skip '&&'
+
+		right, err := p.parseRelationalExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{
+			Left:     left,
+			Operator: operator,
+			Right:    right,
+			Line:     line,
+		}
+	}
+	return left, nil
 }
 
-// EXPR -> ADD-EXPR RELOP ADD-EXPR | ADD-EXPR
+// REL-EXPR -> SHIFT-EXPR RELOP SHIFT-EXPR | SHIFT-EXPR
 // RELOP -> '==' | '!=' | '<' | '<=' | '>' | '>='
 func (p *Parser) parseRelationalExpression() (Expression, error) {
-	left, err := p.parseAddExpression()
+	left, err := p.parseShiftExpression()
 	if err != nil {
 		return nil, err
 	}
@@ -528,7 +1119,7 @@ func (p *Parser) parseRelationalExpression() (Expression, error) {
 		line := p.current.Line
 		p.advance() // skip relational operator
 
-		right, err := p.parseAddExpression()
+		right, err := p.parseShiftExpression()
 		if err != nil {
 			return nil, err
 		}
@@ -544,6 +1135,35 @@ func (p *Parser) parseRelationalExpression() (Expression, error) {
 	return left, nil
 }
 
+// SHIFT-EXPR -> SHIFT-EXPR SHIFTOP ADD-EXPR | ADD-EXPR
+// SHIFTOP -> '<<' | '>>'
+func (p *Parser) parseShiftExpression() (Expression, error) {
+	left, err := p.parseAddExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	// a << b >> c = (a << b) >> c
+	for p.current.Type == TOK_SHL || p.current.Type == TOK_SHR {
+		operator := p.current.Value
+		line := p.current.Line
+		p.advance() // skip operator
+
+		right, err := p.parseAddExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{
+			Left:     left,
+			Operator: operator,
+			Right:    right,
+			Line:     line,
+		}
+	}
+	return left, nil
+}
+
 // ADD-EXPR -> ADD-EXPR ADDOP TERM | TERM
 // ADDOP -> '+' | '-'
 func (p *Parser) parseAddExpression() (Expression, error) {
@@ -574,7 +1194,7 @@ func (p *Parser) parseAddExpression() (Expression, error) {
 }
 
 // TERM -> TERM MULOP FACTOR | FACTOR
-// MULOP -> '*' | '/'
+// MULOP -> '*' | '/' | '%'
 func (p *Parser) parseMultiplyExpression() (Expression, error) {
 	left, err := p.parseFactor()
 	if err != nil {
@@ -604,9 +1224,15 @@ func (p *Parser) parseMultiplyExpression() (Expression, error) {
 
 // xeirizetai ta vasika stoixeia twn expression
 // FACTOR -> '(' EXPR ')' | LOCATION | num | treu | false | METHOD '(' ACTUALS ')'
+//
+//	| '-' FACTOR | '!' FACTOR | '(' TYPE ')' FACTOR
 func (p *Parser) parseFactor() (Expression, error) {
 	switch p.current.Type {
 	case TOK_LPAREN:
+		if isTypeToken(p.peek(1).Type) && p.peek(2).Type == TOK_RPAREN {
+			return p.parseCast()
+		}
+
 		// '(' EXPR ')'
 		p.advance() // skip '('
 
@@ -632,6 +1258,16 @@ func (p *Parser) parseFactor() (Expression, error) {
 			Line:  line,
 		}, nil
 
+	case TOK_FLOATNUM:
+		// num.num
+		value := p.current.Value
+		line := p.current.Line
+		p.advance()
+		return &FloatLiteral{
+			Value: value,
+			Line:  line,
+		}, nil
+
 	case TOK_TRUE:
 		// true
 		line := p.current.Line
@@ -650,6 +1286,26 @@ func (p *Parser) parseFactor() (Expression, error) {
 			Line:  line,
 		}, nil
 
+	case TOK_CHARLIT:
+		// 'A'
+		line := p.current.Line
+		value := p.current.Value[0]
+		p.advance()
+		return &CharLiteral{
+			Value: value,
+			Line:  line,
+		}, nil
+
+	case TOK_STRING:
+		// "HELLO"
+		line := p.current.Line
+		value := p.current.Value
+		p.advance()
+		return &StringLiteral{
+			Value: value,
+			Line:  line,
+		}, nil
+
 	case TOK_ID:
 		// LOCATION | METHOD '(' ACTUALS ')'
 		name := p.current.Value
@@ -678,6 +1334,54 @@ func (p *Parser) parseFactor() (Expression, error) {
 			}, nil
 		}
 
+		// stoixeio pinaka
+		var index Expression
+		if p.current.Type == TOK_LBRACKET {
+			expr, err := p.parseIndex()
+			if err != nil {
+				return nil, err
+			}
+			index = expr
+		}
+
+		// melos struct
+		member := ""
+		if index == nil && p.current.Type == TOK_DOT && p.peek(1).Type == TOK_ID {
+			member = p.parseMember()
+		}
+
+		// pedio ths lekshs
+		if p.current.Type == TOK_DOT {
+			field, err := p.parseField()
+			if err != nil {
+				return nil, err
+			}
+
+			return &FieldExpression{
+				Name:   name,
+				Index:  index,
+				Member: member,
+				Field:  field,
+				Line:   line,
+			}, nil
+		}
+
+		if member != "" {
+			return &MemberExpression{
+				Name:   name,
+				Member: member,
+				Line:   line,
+			}, nil
+		}
+
+		if index != nil {
+			return &IndexExpression{
+				Name:  name,
+				Index: index,
+				Line:  line,
+			}, nil
+		}
+
 		// alliws einai identifier
 		return &Identifier{
 			Name: name,
@@ -700,11 +1404,64 @@ func (p *Parser) parseFactor() (Expression, error) {
 			Line:     line,
 		}, nil
 
+	case TOK_NOT:
+		// logikh arnhsh p.x. !x
+		line := p.current.Line
+		p.advance()
+
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnaryExpression{
+			Operator: "!",
+			Operand:  operand,
+			Line:     line,
+		}, nil
+
 	default:
 		return nil, p.error(fmt.Sprintf("unexpected token in expression: '%s'", p.current.Value))
 	}
 }
 
+// '(' TYPE ')' FACTOR, to cast desmevei opws oi monadikoi telestes
+func (p *Parser) parseCast() (Expression, error) {
+	line := p.current.Line
+	p.advance() // skip '('
+	castType := p.current.Value
+	p.advance() // skip TYPE
+	p.advance() // skip ')'
+
+	operand, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CastExpression{
+		Type:       castType,
+		Expression: operand,
+		Line:       line,
+	}, nil
+}
+
+// '[' EXPR ']'
+func (p *Parser) parseIndex() (Expression, error) {
+	p.advance() // skip '['
+
+	index, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current.Type != TOK_RBRACKET {
+		return nil, p.error(fmt.Sprintf("expected ']', got '%s'", p.current.Value))
+	}
+	p.advance()
+
+	return index, nil
+}
+
 // ACTUALS -> EXPR ARGS | e
 // ARGS -> ',' EXPR ARGS | e
 func (p *Parser) parseActuals() ([]Expression, error) {
@@ -736,6 +1493,40 @@ func (p *Parser) parseActuals() ([]Expression, error) {
 }
 
 // HELPERS
+func (p *Parser) isType() bool {
+	return isTypeToken(p.current.Type) || p.current.Type == TOK_STRUCT
+}
+
+// TYPE -> int | bool | char | float | long | struct id
+func (p *Parser) parseType() (string, error) {
+	if p.current.Type != TOK_STRUCT {
+		typ := p.current.Value
+		p.advance()
+		return typ, nil
+	}
+
+	p.advance() // skip 'struct'
+	if p.current.Type != TOK_ID {
+		return "", p.error(fmt.Sprintf("expected struct name, got '%s'", p.current.Value))
+	}
+	typ := structTypeName(p.current.Value)
+	p.advance()
+	return typ, nil
+}
+
+// tokens tou TYPE (dyo gia struct id)
+func (p *Parser) typeLength() int {
+	if p.current.Type == TOK_STRUCT {
+		return 2
+	}
+	return 1
+}
+
+func isTypeToken(tokenType TokenType) bool {
+	return tokenType == TOK_INT || tokenType == TOK_BOOL || tokenType == TOK_CHAR ||
+		tokenType == TOK_FLOAT || tokenType == TOK_LONG
+}
+
 func (p *Parser) isRelationalOperator() bool {
 	return p.current.Type == TOK_LT || p.current.Type == TOK_LE ||
 		p.current.Type == TOK_GT || p.current.Type == TOK_GE ||
@@ -747,7 +1538,8 @@ func (p *Parser) isAddOperator() bool {
 }
 
 func (p *Parser) isMultiplyOperator() bool {
-	return p.current.Type == TOK_MULTIPLY || p.current.Type == TOK_DIVIDE
+	return p.current.Type == TOK_MULTIPLY || p.current.Type == TOK_DIVIDE ||
+		p.current.Type == TOK_MODULO
 }
 func (p *Parser) advance() {
 	if !p.isAtEnd() {
@@ -760,6 +1552,14 @@ func (p *Parser) advance() {
 	}
 }
 
+// to token offset theseis meta to current
+func (p *Parser) peek(offset int) Token {
+	if p.position+offset < len(p.tokens) {
+		return p.tokens[p.position+offset]
+	}
+	return Token{Type: TOK_EOF, Value: "", Line: -1}
+}
+
 func (p *Parser) isAtEnd() bool {
 	return p.position >= len(p.tokens) || p.current.Type == TOK_EOF
 }
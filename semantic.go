@@ -3,23 +3,106 @@ package main
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/val-makkas/mixal_compiler/mix"
 )
 
 type Symbol struct {
 	Name       string
-	Type       string   // int
-	Kind       string   // variable/parameter/method
-	Offset     int      // thesh sto stack
-	ParamCount int      // arithmos parametron (an einai methodos)
-	ParamTypes []string // types twn parametron (an einai methodos)
-	Line       int      // errors
+	Type       string      // int h int[] gia pinakes
+	Kind       string      // variable/parameter/method
+	Offset     int         // thesh sto stack
+	Size       int         // plhthos stoixeiwn (an einai pinakas)
+	Value      int         // timh (const) h arxikh timh (global), gia float h leksh MIX
+	ParamCount int         // arithmos parametron (an einai methodos)
+	ParamTypes []string    // types twn parametron (an einai methodos)
+	ParamRefs  []bool      // poies parametroi einai ref (an einai methodos)
+	Ref        bool        // parametros ref, krataei th dieythinsh tou orismatos
+	Struct     *StructType // perigrafh tou typou an einai struct (nil alliws)
+	Line       int         // errors
+}
+
+// h parametros i ths methodou pairnei th dieythinsh tou orismatos (ref h struct)
+func (s *Symbol) PassedByAddress(i int) bool {
+	return (i < len(s.ParamRefs) && s.ParamRefs[i]) || isStructType(s.ParamTypes[i])
+}
+
+// typos ths theshs ths parametrou i sto frame, h dieythinsh einai int
+func (s *Symbol) ParamSlotType(i int) string {
+	if s.PassedByAddress(i) {
+		return "int"
+	}
+	return s.ParamTypes[i]
+}
+
+// typos struct: ta melh pianoun synexomenes lekseis me th seira dhlwshs
+type StructType struct {
+	Name    string
+	Members []StructMember
+	Words   int // synolikes lekseis
+}
+
+type StructMember struct {
+	Name   string
+	Type   string
+	Offset int // leksh tou melous apo thn arxh tou struct
+}
+
+func (st *StructType) Member(name string) (StructMember, bool) {
+	for _, member := range st.Members {
+		if member.Name == name {
+			return member, true
+		}
+	}
+	return StructMember{}, false
+}
+
+// megisth timh mias lekshs MIX (binary, 5 bytes twn 6 bits)
+const MAX_WORD = 1<<30 - 1
+
+// megisth timh enos long (dyo lekseis, to rAX)
+const MAX_LONG = 1<<60 - 1
+
+// xarakthres ana grammh tou line printer (24 lekseis)
+const LINE_WIDTH = 120
+
+// builtins, xwris dhlwsh apo ton xrhsth (ylopoiountai me routines I/O)
+var builtins = map[string]*Symbol{
+	"print":    {Name: "print", Type: "void", Kind: "builtin", ParamCount: 1, ParamTypes: []string{"int"}},
+	"printstr": {Name: "printstr", Type: "void", Kind: "builtin", ParamCount: 1, ParamTypes: []string{"string"}},
+	"read":     {Name: "read", Type: "int", Kind: "builtin"},
+	"rotl":     {Name: "rotl", Type: "int", Kind: "builtin", ParamCount: 2, ParamTypes: []string{"int", "int"}},
+	"rotr":     {Name: "rotr", Type: "int", Kind: "builtin", ParamCount: 2, ParamTypes: []string{"int", "int"}},
+}
+
+// lekseis pou pianei to symbolo sto frame h sth mnhmh
+func (s *Symbol) Words() int {
+	// oi parametroi ref kai struct pairnoun mono th dieythinsh
+	if s.Ref {
+		return 1
+	}
+	if s.Struct != nil && s.Kind != "parameter" {
+		return s.Struct.Words
+	}
+	return max(s.Size, 1) * typeWords(elementType(s.Type))
 }
 
 // pinakas symbolwn gia ena scope
 type SymbolTable struct {
 	Symbols  map[string]*Symbol
-	Name     string // onoma tou scope
-	VarCount int    // arithmos metavlitwn sto scope
+	Name     string       // onoma tou scope
+	VarCount int          // arithmos metavlitwn sto scope
+	Parent   *SymbolTable // exwteriko scope (nil gia to global)
+
+	// ta eswterika blocks synexizoun tis theseis tou gonea, ara ta adelfa
+	// blocks moirazontai tis idies lekseis kai to frame exei to megisto
+	Children  []*SymbolTable
+	FrameSize int          // theseis tou frame (mono ston pinaka ths methodou)
+	frame     *SymbolTable // pinakas ths methodou
 }
 
 func NewSymbolTable(name string) *SymbolTable {
@@ -30,6 +113,16 @@ func NewSymbolTable(name string) *SymbolTable {
 	}
 }
 
+// neo scope gia block mesa se methodo
+func NewScope(name string, parent *SymbolTable) *SymbolTable {
+	scope := NewSymbolTable(name)
+	scope.Parent = parent
+	scope.VarCount = parent.VarCount
+	scope.frame = parent.frame
+	parent.Children = append(parent.Children, scope)
+	return scope
+}
+
 // add symbolo me duplicate check
 func (st *SymbolTable) AddSymbol(symbol *Symbol) error {
 	if _, exists := st.Symbols[symbol.Name]; exists {
@@ -38,10 +131,13 @@ func (st *SymbolTable) AddSymbol(symbol *Symbol) error {
 
 	st.Symbols[symbol.Name] = symbol
 
-	// enhmerwsi offset kai varCount
+	// enhmerwsi offset kai varCount, oi pinakes pairnoun synexomenes lekseis
 	if symbol.Kind == "variable" || symbol.Kind == "parameter" {
 		symbol.Offset = st.VarCount
-		st.VarCount++
+		st.VarCount += symbol.Words()
+		if st.frame != nil {
+			st.frame.FrameSize = max(st.frame.FrameSize, st.VarCount)
+		}
 	}
 	return nil
 }
@@ -51,27 +147,47 @@ func (st *SymbolTable) Lookup(name string) (*Symbol, bool) {
 	return symbol, exists
 }
 
+// anazhthsh sto scope kai meta sta exwterika (p.x. methodos -> global)
+func (st *SymbolTable) Resolve(name string) (*Symbol, bool) {
+	for table := st; table != nil; table = table.Parent {
+		if symbol, exists := table.Symbols[name]; exists {
+			return symbol, true
+		}
+	}
+	return nil, false
+}
+
 type SemanticAnalyzer struct {
-	//Global scope gia methodous
+	//Global scope gia methodous, global metavlhtes kai stathere
 	globalSymbols *SymbolTable
 
 	//Symbol table gia kathe methodo
 	methodTables map[string]*SymbolTable
 
+	// orismoi struct kata onoma
+	structs map[string]*StructType
+
 	currentMethod string       // current methodos
 	currentTable  *SymbolTable // current symbol table
 
 	// Errors
 	errors []string
 
+	// Warnings (p.x. shadowing), den stamatoun th metaglwttish
+	warnings   []string
+	scopeCount int // arithmhsh twn blocks ths methodou
+
 	// Loop tracking
-	loopDepth int
+	loopDepth   int
+	switchDepth int      // to break epitrepetai kai mesa se switch
+	loopLabels  []string // etiketes twn broxgwn pou periexoun to trexon statement
 }
 
 func NewSemanticAnalyzer() *SemanticAnalyzer {
 	return &SemanticAnalyzer{
 		globalSymbols: NewSymbolTable("global"),
 		methodTables:  make(map[string]*SymbolTable),
+		structs:       make(map[string]*StructType),
 		errors:        []string{},
 		loopDepth:     0,
 	}
@@ -79,6 +195,13 @@ func NewSemanticAnalyzer() *SemanticAnalyzer {
 
 func (s *SemanticAnalyzer) Analyze(ast *AST) (map[string]*SymbolTable, error) {
 
+	// oi typoi struct prin apo ola ta ypoloipa
+	for _, decl := range ast.Structs {
+		if err := s.analyzeStruct(decl); err != nil {
+			s.errors = append(s.errors, err.Error())
+		}
+	}
+
 	// Syllegw ta method signatures
 	for _, method := range ast.Methods {
 		if err := s.addMethodSignature(method); err != nil {
@@ -91,6 +214,14 @@ func (s *SemanticAnalyzer) Analyze(ast *AST) (map[string]*SymbolTable, error) {
 		s.errors = append(s.errors, "error: no 'main' method found")
 	}
 
+	// global metavlhtes kai stathere, me th seira pou dhlwnontai
+	s.currentTable = s.globalSymbols
+	for _, decl := range ast.Globals {
+		if err := s.analyzeGlobalDeclaration(decl); err != nil {
+			s.errors = append(s.errors, err.Error())
+		}
+	}
+
 	// method body analysis
 	for _, method := range ast.Methods {
 		if err := s.analyzeMethod(method); err != nil {
@@ -111,10 +242,27 @@ func (s *SemanticAnalyzer) Analyze(ast *AST) (map[string]*SymbolTable, error) {
 }
 
 func (s *SemanticAnalyzer) addMethodSignature(method Method) error {
+	if _, exists := builtins[method.Name]; exists {
+		return fmt.Errorf("method '%s' at line %d redefines a builtin", method.Name, method.Line)
+	}
+
+	if isStructType(method.ReturnType) {
+		return fmt.Errorf("method '%s' at line %d cannot return a struct", method.Name, method.Line)
+	}
+
 	// overload checking
 	paramTypes := make([]string, len(method.Parameters))
+	paramRefs := make([]bool, len(method.Parameters))
 	for i, param := range method.Parameters {
+		if _, err := s.lookupStruct(param.Type, param.Line); err != nil {
+			return err
+		}
+		if param.Ref && isStructType(param.Type) {
+			return fmt.Errorf("ref parameter '%s' at line %d cannot be a struct, structs are already passed by address",
+				param.Name, param.Line)
+		}
 		paramTypes[i] = param.Type
+		paramRefs[i] = param.Ref
 	}
 
 	methodSymbol := &Symbol{
@@ -123,6 +271,7 @@ func (s *SemanticAnalyzer) addMethodSignature(method Method) error {
 		Kind:       "method",
 		ParamCount: len(method.Parameters),
 		ParamTypes: paramTypes,
+		ParamRefs:  paramRefs,
 		Line:       method.Line,
 	}
 
@@ -132,20 +281,29 @@ func (s *SemanticAnalyzer) addMethodSignature(method Method) error {
 func (s *SemanticAnalyzer) analyzeMethod(method Method) error {
 	// dhmiourgw local symbol table gia th methodo
 	methodTable := NewSymbolTable(method.Name)
+	methodTable.Parent = s.globalSymbols
+	methodTable.frame = methodTable
 	s.methodTables[method.Name] = methodTable
 
 	// set current method and table
 	s.currentMethod = method.Name
 	s.currentTable = methodTable
 	s.loopDepth = 0
+	s.scopeCount = 0
 
 	// add parametrwn sto method scope
 	for _, param := range method.Parameters {
+		structType, err := s.lookupStruct(param.Type, param.Line)
+		if err != nil {
+			return err
+		}
 		paramSymbol := &Symbol{
-			Name: param.Name,
-			Type: param.Type,
-			Kind: "parameter",
-			Line: param.Line,
+			Name:   param.Name,
+			Type:   param.Type,
+			Kind:   "parameter",
+			Struct: structType,
+			Ref:    param.Ref,
+			Line:   param.Line,
 		}
 
 		if err := methodTable.AddSymbol(paramSymbol); err != nil {
@@ -156,6 +314,58 @@ func (s *SemanticAnalyzer) analyzeMethod(method Method) error {
 	return s.analyzeBlock(method.Body)
 }
 
+// ta melh einai scalar metablhtes xwris arxikh timh
+func (s *SemanticAnalyzer) analyzeStruct(decl StructDeclaration) error {
+	if _, exists := s.structs[decl.Name]; exists {
+		return fmt.Errorf("struct '%s' at line %d is already defined", decl.Name, decl.Line)
+	}
+
+	structType := &StructType{Name: decl.Name}
+	for _, memberDecl := range decl.Members {
+		if isStructType(memberDecl.Type) {
+			return fmt.Errorf("member of struct '%s' at line %d cannot be a struct", decl.Name, memberDecl.Line)
+		}
+
+		for _, variable := range memberDecl.Variables {
+			if variable.SizeExpr != nil {
+				return fmt.Errorf("member '%s' of struct '%s' at line %d cannot be an array", variable.Name, decl.Name, memberDecl.Line)
+			}
+			if variable.InitialValue != nil {
+				return fmt.Errorf("member '%s' of struct '%s' at line %d cannot be initialized", variable.Name, decl.Name, memberDecl.Line)
+			}
+			if _, exists := structType.Member(variable.Name); exists {
+				return fmt.Errorf("duplicate member '%s' in struct '%s' at line %d", variable.Name, decl.Name, memberDecl.Line)
+			}
+
+			structType.Members = append(structType.Members, StructMember{
+				Name:   variable.Name,
+				Type:   memberDecl.Type,
+				Offset: structType.Words,
+			})
+			structType.Words += typeWords(memberDecl.Type)
+		}
+	}
+
+	if len(structType.Members) == 0 {
+		return fmt.Errorf("struct '%s' at line %d has no members", decl.Name, decl.Line)
+	}
+
+	s.structs[decl.Name] = structType
+	return nil
+}
+
+// o orismos enos typou struct (nil gia tous scalar typous)
+func (s *SemanticAnalyzer) lookupStruct(typ string, line int) (*StructType, error) {
+	if !isStructType(typ) {
+		return nil, nil
+	}
+	structType, exists := s.structs[strings.TrimPrefix(typ, "struct ")]
+	if !exists {
+		return nil, fmt.Errorf("undefined %s at line %d", typ, line)
+	}
+	return structType, nil
+}
+
 func (s *SemanticAnalyzer) analyzeBlock(block Block) error {
 	// declarations apo vars (panta prwtes)
 	for _, decl := range block.Declarations {
@@ -174,13 +384,56 @@ func (s *SemanticAnalyzer) analyzeBlock(block Block) error {
 	return nil
 }
 
+// block me diko tou scope, oi dhlwseis tou kryvoun ta exwterika onomata
+func (s *SemanticAnalyzer) analyzeNestedBlock(block *Block) error {
+	s.scopeCount++
+	scope := NewScope(fmt.Sprintf("%s.%d", s.currentMethod, s.scopeCount), s.currentTable)
+	block.Scope = scope
+
+	outer := s.currentTable
+	s.currentTable = scope
+	err := s.analyzeBlock(*block)
+	s.currentTable = outer
+
+	return err
+}
+
+func (s *SemanticAnalyzer) Warnings() []string {
+	return s.warnings
+}
+
 func (s *SemanticAnalyzer) analyzeDeclaration(decl Declaration) error {
-	for _, variable := range decl.Variables {
+	for i := range decl.Variables {
+		if err := s.analyzeArraySize(&decl.Variables[i], decl.Line); err != nil {
+			return err
+		}
+		variable := decl.Variables[i]
+
+		// shadowing metavlhths h parametrou apo exwteriko block
+		if outer := s.currentTable.Parent; outer != s.globalSymbols {
+			if symbol, exists := outer.Resolve(variable.Name); exists && (symbol.Kind == "variable" || symbol.Kind == "parameter") {
+				s.warnings = append(s.warnings, fmt.Sprintf("declaration of '%s' at line %d shadows %s declared at line %d",
+					variable.Name, decl.Line, symbol.Kind, symbol.Line))
+			}
+		}
+
+		structType, err := s.analyzeStructVariable(decl, variable)
+		if err != nil {
+			return err
+		}
+
+		varType := decl.Type
+		if variable.Size > 0 {
+			varType = arrayType(decl.Type)
+		}
+
 		varSymbol := &Symbol{
-			Name: variable.Name,
-			Type: decl.Type, // mono int
-			Kind: "variable",
-			Line: decl.Line,
+			Name:   variable.Name,
+			Type:   varType,
+			Kind:   "variable",
+			Size:   variable.Size,
+			Struct: structType,
+			Line:   decl.Line,
 		}
 
 		// prosthiki sto scope
@@ -188,24 +441,162 @@ func (s *SemanticAnalyzer) analyzeDeclaration(decl Declaration) error {
 			return err
 		}
 
+		// pinakas me keimeno
+		if variable.Size > 0 && variable.InitialValue != nil {
+			if err := s.analyzeArrayInitializer(decl, variable); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// elegxos gia init
 		if variable.InitialValue != nil {
 			exprType, err := s.analyzeExpression(variable.InitialValue)
 			if err != nil {
 				return err
 			}
+			exprType = promote(&decl.Variables[i].InitialValue, exprType, decl.Type, decl.Line)
 
 			// type compatibility
-			if exprType != "int" {
-				return fmt.Errorf("type mismatch in variable initialization at line %d: expected int, got %s",
-					decl.Line, exprType)
+			if exprType != decl.Type {
+				return fmt.Errorf("type mismatch in variable initialization at line %d: expected %s, got %s",
+					decl.Line, decl.Type, exprType)
+			}
+
+		}
+	}
+	return nil
+}
+
+// global metavlhtes kai stathere, oi arxikes times prepei na einai stathere
+func (s *SemanticAnalyzer) analyzeGlobalDeclaration(decl Declaration) error {
+	kind := "global"
+	if decl.Const {
+		kind = "const"
+	}
+
+	for i := range decl.Variables {
+		variable := decl.Variables[i]
+		if decl.Const && variable.SizeExpr != nil {
+			return fmt.Errorf("constant '%s' cannot be an array at line %d", variable.Name, decl.Line)
+		}
+		if decl.Const && variable.InitialValue == nil {
+			return fmt.Errorf("constant '%s' must be initialized at line %d", variable.Name, decl.Line)
+		}
+		if decl.Const && isStructType(decl.Type) {
+			return fmt.Errorf("constant '%s' cannot be a struct at line %d", variable.Name, decl.Line)
+		}
+
+		if err := s.analyzeArraySize(&decl.Variables[i], decl.Line); err != nil {
+			return err
+		}
+		variable = decl.Variables[i]
+
+		structType, err := s.analyzeStructVariable(decl, variable)
+		if err != nil {
+			return err
+		}
+
+		varType := decl.Type
+		if variable.Size > 0 {
+			varType = arrayType(decl.Type)
+		}
+
+		symbol := &Symbol{
+			Name:   variable.Name,
+			Type:   varType,
+			Kind:   kind,
+			Size:   variable.Size,
+			Struct: structType,
+			Line:   decl.Line,
+		}
+
+		if variable.Size > 0 && variable.InitialValue != nil {
+			// to keimeno grafetai kateytheian sth mnhmh tou pinaka
+			if err := s.analyzeArrayInitializer(decl, variable); err != nil {
+				return err
+			}
+		} else if variable.InitialValue != nil {
+			exprType, err := s.analyzeExpression(variable.InitialValue)
+			if err != nil {
+				return err
+			}
+			exprType = promote(&decl.Variables[i].InitialValue, exprType, decl.Type, decl.Line)
+			if exprType != decl.Type {
+				return fmt.Errorf("type mismatch in initialization of '%s' at line %d: expected %s, got %s",
+					variable.Name, decl.Line, decl.Type, exprType)
 			}
 
+			// h timh grafetai ws CON, ara ypologizetai edw
+			value, ok := evaluateConstant(decl.Variables[i].InitialValue, s.globalSymbols)
+			if !ok {
+				return fmt.Errorf("initializer of '%s' at line %d must be a constant expression", variable.Name, decl.Line)
+			}
+			symbol.Value = value
+		}
+
+		if err := s.globalSymbols.AddSymbol(symbol); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// to megethos tou pinaka einai statherh ekfrash int > 0 (p.x. N apo const int N = 10)
+func (s *SemanticAnalyzer) analyzeArraySize(variable *Variable, line int) error {
+	if variable.SizeExpr == nil {
+		return nil
+	}
+
+	sizeType, err := s.analyzeExpression(variable.SizeExpr)
+	if err != nil {
+		return err
+	}
+	size, ok := evaluateConstant(variable.SizeExpr, s.currentTable)
+	if sizeType != "int" || !ok {
+		return fmt.Errorf("size of array '%s' at line %d must be a constant int expression", variable.Name, line)
+	}
+	if size <= 0 {
+		return fmt.Errorf("size of array '%s' at line %d must be positive, got %d", variable.Name, line, size)
+	}
+
+	variable.Size = size
+	return nil
+}
+
+// metablhth typou struct: oxi pinakas kai xwris arxikh timh
+func (s *SemanticAnalyzer) analyzeStructVariable(decl Declaration, variable Variable) (*StructType, error) {
+	structType, err := s.lookupStruct(decl.Type, decl.Line)
+	if err != nil || structType == nil {
+		return nil, err
+	}
+
+	if variable.Size > 0 {
+		return nil, fmt.Errorf("array '%s' at line %d cannot hold structs", variable.Name, decl.Line)
+	}
+	if variable.InitialValue != nil {
+		return nil, fmt.Errorf("struct '%s' at line %d cannot be initialized", variable.Name, decl.Line)
+	}
+	return structType, nil
+}
+
+// pinakas char pairnei enan xarakthra ana stoixeio, pinakas int 5 ana leksh
+func (s *SemanticAnalyzer) analyzeArrayInitializer(decl Declaration, variable Variable) error {
+	text, ok := variable.InitialValue.(*StringLiteral)
+	if !ok {
+		return fmt.Errorf("array '%s' at line %d can only be initialized with a string", variable.Name, decl.Line)
+	}
+	if decl.Type != "char" && decl.Type != "int" {
+		return fmt.Errorf("%s array '%s' at line %d cannot be initialized with a string", decl.Type, variable.Name, decl.Line)
+	}
+
+	if words := stringWords(decl.Type, text.Value); words > variable.Size {
+		return fmt.Errorf("string of %d characters does not fit in '%s' of size %d at line %d",
+			len(text.Value), variable.Name, variable.Size, decl.Line)
+	}
+	return nil
+}
+
 // statement switch
 func (s *SemanticAnalyzer) analyzeStatement(stmt Statement) error {
 	switch stmt := stmt.(type) {
@@ -215,12 +606,26 @@ func (s *SemanticAnalyzer) analyzeStatement(stmt Statement) error {
 		return s.analyzeIfStatement(stmt)
 	case *WhileStatement:
 		return s.analyzeWhileStatement(stmt)
+	case *ForStatement:
+		return s.analyzeForStatement(stmt)
+	case *DoWhileStatement:
+		return s.analyzeDoWhileStatement(stmt)
+	case *SwitchStatement:
+		return s.analyzeSwitchStatement(stmt)
+	case *LabeledStatement:
+		return s.analyzeLabeledStatement(stmt)
 	case *BreakStatement:
 		return s.analyzeBreakStatement(stmt)
+	case *ContinueStatement:
+		return s.analyzeContinueStatement(stmt)
 	case *BlockStatement:
-		return s.analyzeBlock(stmt.Block)
+		return s.analyzeNestedBlock(&stmt.Block)
+	case *ExpressionStatement:
+		return s.analyzeExpressionStatement(stmt)
 	case *Assignment:
 		return s.analyzeAssignment(stmt)
+	case *AsmStatement:
+		return s.analyzeAsmStatement(stmt)
 	default:
 		return fmt.Errorf("unknown statement type: %T", stmt)
 	}
@@ -230,165 +635,771 @@ func (s *SemanticAnalyzer) analyzeStatement(stmt Statement) error {
 func (s *SemanticAnalyzer) analyzeExpression(expr Expression) (string, error) {
 	switch e := expr.(type) {
 	case *NumberLiteral:
-		return "int", nil
+		return literalType(e), nil
+	case *FloatLiteral:
+		if _, ok := floatConstant(e); !ok {
+			return "", fmt.Errorf("float literal %s at line %d is out of range", e.Value, e.Line)
+		}
+		return "float", nil
+	case *CastExpression:
+		return s.analyzeCastExpression(e)
 	case *BooleanLiteral:
-		return "int", nil // true = 1 , false = 0
+		return "bool", nil // true = 1 , false = 0 ston kwdika
+	case *CharLiteral:
+		return "char", nil
+	case *StringLiteral:
+		return "string", nil
 	case *Identifier:
 		return s.analyzeIdentifier(e)
+	case *IndexExpression:
+		return s.analyzeIndexExpression(e)
+	case *FieldExpression:
+		return s.analyzeFieldExpression(e)
+	case *MemberExpression:
+		return s.analyzeMemberExpression(e)
 	case *BinaryExpression:
 		return s.analyzeBinaryExpression(e)
 	case *UnaryExpression:
 		return s.analyzeUnaryExpression(e)
 	case *MethodCall:
-		return s.analyzeMethodCall(e)
+		methodType, err := s.analyzeMethodCall(e)
+		if err == nil && methodType == "void" {
+			return "", fmt.Errorf("void method '%s' used as a value at line %d", e.Name, e.Line)
+		}
+		return methodType, err
 	default:
 		return "", fmt.Errorf("unknown expression type: %T", expr)
 	}
 }
 
 func (s *SemanticAnalyzer) analyzeIdentifier(expr *Identifier) (string, error) {
-	symbol, exists := s.currentTable.Lookup(expr.Name)
+	symbol, exists := s.currentTable.Resolve(expr.Name)
 	if !exists {
 		return "", fmt.Errorf("undefined identifier '%s' at line %d", expr.Name, expr.Line)
 	}
 
+	if symbol.Kind == "method" {
+		return "", fmt.Errorf("method '%s' used as a variable at line %d", expr.Name, expr.Line)
+	}
+
+	if isArrayType(symbol.Type) {
+		return "", fmt.Errorf("array '%s' used without index at line %d", expr.Name, expr.Line)
+	}
+
+	// to struct den xwraei sto rA, mono ta melh tou h h dieythinsh tou ws orisma
+	if symbol.Struct != nil {
+		return "", fmt.Errorf("struct '%s' used as a value at line %d", expr.Name, expr.Line)
+	}
+
 	return symbol.Type, nil
 }
 
-func (s *SemanticAnalyzer) analyzeBinaryExpression(expr *BinaryExpression) (string, error) {
-	// aristerh pleura
-	leftType, err := s.analyzeExpression(expr.Left)
-	if err != nil {
-		return "", err
+func (s *SemanticAnalyzer) analyzeMemberExpression(expr *MemberExpression) (string, error) {
+	symbol, exists := s.currentTable.Resolve(expr.Name)
+	if !exists {
+		return "", fmt.Errorf("undefined identifier '%s' at line %d", expr.Name, expr.Line)
 	}
+	return s.memberType(symbol, expr.Member, expr.Line)
+}
 
-	// dexia pleura
-	rightType, err := s.analyzeExpression(expr.Right)
-	if err != nil {
-		return "", err
+// typos tou melous enos struct
+func (s *SemanticAnalyzer) memberType(symbol *Symbol, member string, line int) (string, error) {
+	if symbol.Struct == nil {
+		return "", fmt.Errorf("'%s' is not a struct at line %d", symbol.Name, line)
 	}
 
-	// type compatibility
-	if leftType != "int" || rightType != "int" {
-		return "", fmt.Errorf("type mismatch in binary expression at line %d: expected int, got %s and %s",
-			expr.Line, leftType, rightType)
+	structMember, exists := symbol.Struct.Member(member)
+	if !exists {
+		return "", fmt.Errorf("struct '%s' has no member '%s' at line %d", symbol.Struct.Name, member, line)
 	}
-
-	return "int", nil
+	return structMember.Type, nil
 }
 
-func (s *SemanticAnalyzer) analyzeUnaryExpression(expr *UnaryExpression) (string, error) {
-	operandType, err := s.analyzeExpression(expr.Operand)
-	if err != nil {
-		return "", err
+func (s *SemanticAnalyzer) analyzeIndexExpression(expr *IndexExpression) (string, error) {
+	symbol, exists := s.currentTable.Resolve(expr.Name)
+	if !exists {
+		return "", fmt.Errorf("undefined identifier '%s' at line %d", expr.Name, expr.Line)
 	}
 
-	if operandType != "int" {
-		return "", fmt.Errorf("type mismatch in unary expression at line %d: expected int, got %s",
-			expr.Line, operandType)
+	if err := s.analyzeArrayIndex(symbol, expr.Index, expr.Line); err != nil {
+		return "", err
 	}
-	return "int", nil
+	return elementType(symbol.Type), nil
 }
 
-func (s *SemanticAnalyzer) analyzeMethodCall(expr *MethodCall) (string, error) {
-	// anazhthsh methodou global scope
-	methodSymbol, exists := s.globalSymbols.Lookup(expr.Name)
+// pedio (L:R) mias metablhths, enos stoixeiou pinaka h melous, diavazetai san int
+func (s *SemanticAnalyzer) analyzeFieldExpression(expr *FieldExpression) (string, error) {
+	symbol, exists := s.currentTable.Resolve(expr.Name)
 	if !exists {
-		return "", fmt.Errorf("undefined method '%s' at line %d", expr.Name, expr.Line)
+		return "", fmt.Errorf("undefined identifier '%s' at line %d", expr.Name, expr.Line)
 	}
 
-	// elegxos parametron
-	if len(expr.Arguments) != methodSymbol.ParamCount {
-		return "", fmt.Errorf("method '%s' called with wrong number of arguments at line %d: expected %d, got %d",
-			expr.Name, expr.Line, methodSymbol.ParamCount, len(expr.Arguments))
+	if symbol.Kind == "method" {
+		return "", fmt.Errorf("method '%s' used as a variable at line %d", expr.Name, expr.Line)
 	}
 
-	// elegxos typwn parametron
-	for i, arg := range expr.Arguments {
-		argType, err := s.analyzeExpression(arg)
+	if err := s.analyzeField(symbol, expr.Index, expr.Member, expr.Field, expr.Line); err != nil {
+		return "", err
+	}
+	return "int", nil
+}
+
+// elegxos 0 <= L <= R <= 5 kai oti h timh pianei mia leksh
+func (s *SemanticAnalyzer) analyzeField(symbol *Symbol, index Expression, member string, field Field, line int) error {
+	if field.Left < 0 || field.Left > field.Right || field.Right > mix.WordBytes {
+		return fmt.Errorf("invalid field (%d:%d) of '%s' at line %d: expected 0 <= L <= R <= %d",
+			field.Left, field.Right, symbol.Name, line, mix.WordBytes)
+	}
+
+	valueType := symbol.Type
+	if member != "" {
+		memberType, err := s.memberType(symbol, member, line)
 		if err != nil {
-			return "", err
+			return err
 		}
-
-		if argType != methodSymbol.ParamTypes[i] {
-			return "", fmt.Errorf("type mismatch in argument %d of method '%s' at line %d: expected %s, got %s",
-				i+1, expr.Name, expr.Line, methodSymbol.ParamTypes[i], argType)
+		valueType = memberType
+	} else if index != nil {
+		if err := s.analyzeArrayIndex(symbol, index, line); err != nil {
+			return err
 		}
+		valueType = elementType(symbol.Type)
+	} else if isArrayType(symbol.Type) {
+		return fmt.Errorf("array '%s' used without index at line %d", symbol.Name, line)
 	}
 
-	return methodSymbol.Type, nil
+	if typeWords(valueType) != 1 {
+		return fmt.Errorf("field (%d:%d) of '%s' at line %d needs a one-word value, got %s",
+			field.Left, field.Right, symbol.Name, line, valueType)
+	}
+	return nil
 }
 
-func (s *SemanticAnalyzer) analyzeReturnStatement(stmt *ReturnStatement) error {
-	// elegxos return
-	exprType, err := s.analyzeExpression(stmt.Expression)
+// elegxos oti to symbolo einai pinakas kai o deikths int entos oriwn (an einai statheros)
+func (s *SemanticAnalyzer) analyzeArrayIndex(symbol *Symbol, index Expression, line int) error {
+	if !isArrayType(symbol.Type) {
+		return fmt.Errorf("'%s' is not an array at line %d", symbol.Name, line)
+	}
+
+	indexType, err := s.analyzeExpression(index)
 	if err != nil {
 		return err
 	}
-
-	// elegxos an to type tou return tairiazei me to method type
-	methodSymbol, exists := s.globalSymbols.Symbols[s.currentMethod]
-	if !exists {
-		return fmt.Errorf("return statement outside of method at line %d", stmt.Line)
+	if indexType != "int" {
+		return fmt.Errorf("array index of '%s' at line %d must be int, got %s", symbol.Name, line, indexType)
 	}
 
-	if exprType != methodSymbol.Type {
-		return fmt.Errorf("type mismatch in return statement at line %d: expected %s, got %s",
-			stmt.Line, methodSymbol.Type, exprType)
+	if literal, ok := index.(*NumberLiteral); ok {
+		if value, err := strconv.Atoi(literal.Value); err == nil && value >= symbol.Size {
+			return fmt.Errorf("array index %d out of bounds for '%s' of size %d at line %d",
+				value, symbol.Name, symbol.Size, line)
+		}
 	}
 	return nil
 }
 
-func (s *SemanticAnalyzer) analyzeIfStatement(stmt *IfStatement) error {
-	_, err := s.analyzeExpression(stmt.Condition)
+func (s *SemanticAnalyzer) analyzeBinaryExpression(expr *BinaryExpression) (string, error) {
+	// aristerh pleura
+	leftType, err := s.analyzeExpression(expr.Left)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// analysh to then
-	if err := s.analyzeStatement(stmt.ThenStmt); err != nil {
-		return err
+	// dexia pleura
+	rightType, err := s.analyzeExpression(expr.Right)
+	if err != nil {
+		return "", err
 	}
 
-	// analysh to else
-	if stmt.ElseStmt != nil {
-		if err := s.analyzeStatement(stmt.ElseStmt); err != nil {
-			return err
+	// oi metatopiseis den kanoun promotion, metakinoun bytes mias lekshs
+	if isShift(expr.Operator) {
+		expr.OperandType = "int"
+		if leftType != "int" || rightType != "int" {
+			return "", fmt.Errorf("type mismatch in '%s' at line %d: expected int, got %s and %s",
+				expr.Operator, expr.Line, leftType, rightType)
 		}
+		return "int", s.analyzeShiftCount(expr.Right, expr.Line)
 	}
 
-	return nil
-}
+	// se mikth ekfrash o int telesths ginetai float
+	if isNumeric(leftType) && isNumeric(rightType) {
+		leftType = promote(&expr.Left, leftType, rightType, expr.Line)
+		rightType = promote(&expr.Right, rightType, leftType, expr.Line)
+	}
+	expr.OperandType = leftType
+
+	// type compatibility analoga me ton telesth
+	switch expr.Operator {
+	case "&&", "||":
+		if leftType != "bool" || rightType != "bool" {
+			return "", fmt.Errorf("type mismatch in '%s' at line %d: expected bool, got %s and %s",
+				expr.Operator, expr.Line, leftType, rightType)
+		}
+		return "bool", nil
 
-func (s *SemanticAnalyzer) analyzeWhileStatement(stmt *WhileStatement) error {
-	// elegxos condition
-	_, err := s.analyzeExpression(stmt.Condition)
-	if err != nil {
-		return err
+	case "==", "!=":
+		if leftType != rightType {
+			return "", fmt.Errorf("type mismatch in '%s' at line %d: cannot compare %s with %s",
+				expr.Operator, expr.Line, leftType, rightType)
+		}
+		return "bool", nil
+	}
+
+	// oi xarakthres sygkrinontai me th seira twn kwdikwn tous
+	if isRelational(expr.Operator) && leftType == "char" && rightType == "char" {
+		return "bool", nil
+	}
+
+	// FADD, FSUB, FMUL, FDIV kai FCMP gia float (to '%' menei mono gia
+	// akeraious), routines sto rAX gia long
+	wide := leftType == "long" || (leftType == "float" && expr.Operator != "%")
+	if leftType == rightType && wide {
+		if isRelational(expr.Operator) {
+			return "bool", nil
+		}
+		return leftType, s.analyzeConstantOverflow(expr, leftType)
+	}
+
+	if leftType != "int" || rightType != "int" {
+		return "", fmt.Errorf("type mismatch in binary expression at line %d: expected int, got %s and %s",
+			expr.Line, leftType, rightType)
+	}
+
+	if isRelational(expr.Operator) {
+		return "bool", nil
+	}
+
+	// diairesh (h modulo) me stathero mhden
+	isDivision := expr.Operator == "/" || expr.Operator == "%"
+	if literal, ok := expr.Right.(*NumberLiteral); ok && isDivision && literal.Value == "0" {
+		return "", fmt.Errorf("division by zero at line %d", expr.Line)
+	}
+
+	return "int", s.analyzeConstantOverflow(expr, "int")
+}
+
+// stathera ekfrash pou den xwraei ston typo ths: sto runtime tha stamatouse
+// sto ERROVF, ara einai sfalma hdh kata th metaglwttish
+func (s *SemanticAnalyzer) analyzeConstantOverflow(expr *BinaryExpression, exprType string) error {
+	if expr.Operator != "+" && expr.Operator != "-" && expr.Operator != "*" {
+		return nil
+	}
+	if _, ok := evaluateConstant(expr.Left, s.currentTable); !ok {
+		return nil
+	}
+	if _, ok := evaluateConstant(expr.Right, s.currentTable); !ok {
+		return nil
+	}
+	if _, ok := evaluateConstant(expr, s.currentTable); !ok {
+		return fmt.Errorf("result of constant expression at line %d does not fit in %s", expr.Line, exprType)
+	}
+	return nil
+}
+
+// to plhthos twn bytes den mporei na einai arnhtiko (an einai stathero)
+func (s *SemanticAnalyzer) analyzeShiftCount(count Expression, line int) error {
+	if value, ok := evaluateConstant(count, s.currentTable); ok && value < 0 {
+		return fmt.Errorf("negative shift count %d at line %d", value, line)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) analyzeUnaryExpression(expr *UnaryExpression) (string, error) {
+	operandType, err := s.analyzeExpression(expr.Operand)
+	if err != nil {
+		return "", err
+	}
+
+	// to '!' thelei bool, to '-' int, long h float
+	expected := "int"
+	if expr.Operator == "!" {
+		expected = "bool"
+	} else if isNumeric(operandType) {
+		expected = operandType
+	}
+
+	if operandType != expected {
+		return "", fmt.Errorf("type mismatch in unary expression at line %d: expected %s, got %s",
+			expr.Line, expected, operandType)
+	}
+	return expected, nil
+}
+
+// metatropes anamesa sto int kai se float h long
+func (s *SemanticAnalyzer) analyzeCastExpression(expr *CastExpression) (string, error) {
+	exprType, err := s.analyzeExpression(expr.Expression)
+	if err != nil {
+		return "", err
+	}
+
+	if !isNumeric(exprType) || !isNumeric(expr.Type) || (exprType != "int" && expr.Type != "int" && exprType != expr.Type) {
+		return "", fmt.Errorf("cannot cast %s to %s at line %d", exprType, expr.Type, expr.Line)
+	}
+	expr.FromType = exprType
+	return expr.Type, nil
+}
+
+// oi synthikes twn if/while/for/do prepei na einai bool
+func (s *SemanticAnalyzer) analyzeCondition(condition Expression, statement string, line int) error {
+	condType, err := s.analyzeExpression(condition)
+	if err != nil {
+		return err
+	}
+
+	if condType != "bool" {
+		return fmt.Errorf("condition of %s at line %d must be bool, got %s", statement, line, condType)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) analyzeMethodCall(expr *MethodCall) (string, error) {
+	// anazhthsh methodou global scope, meta sta builtins
+	methodSymbol, exists := s.globalSymbols.Lookup(expr.Name)
+	if !exists {
+		methodSymbol, exists = builtins[expr.Name]
+	}
+	if !exists || (methodSymbol.Kind != "method" && methodSymbol.Kind != "builtin") {
+		return "", fmt.Errorf("undefined method '%s' at line %d", expr.Name, expr.Line)
+	}
+
+	// to printstr typwnei ena literal se mia grammh
+	if expr.Name == "printstr" && methodSymbol.Kind == "builtin" && len(expr.Arguments) == 1 {
+		if text, ok := expr.Arguments[0].(*StringLiteral); ok && len(text.Value) > LINE_WIDTH {
+			return "", fmt.Errorf("string of %d characters at line %d does not fit in a printer line of %d",
+				len(text.Value), expr.Line, LINE_WIDTH)
+		}
+	}
+
+	// elegxos parametron
+	if len(expr.Arguments) != methodSymbol.ParamCount {
+		return "", fmt.Errorf("method '%s' called with wrong number of arguments at line %d: expected %d, got %d",
+			expr.Name, expr.Line, methodSymbol.ParamCount, len(expr.Arguments))
+	}
+
+	// elegxos typwn parametron
+	for i, arg := range expr.Arguments {
+		var argType string
+		var err error
+		if isStructType(methodSymbol.ParamTypes[i]) {
+			argType, err = s.analyzeStructArgument(arg, i, expr)
+		} else if methodSymbol.PassedByAddress(i) {
+			// h dieythinsh den metatrepetai, ara xwris promotion
+			argType, err = s.analyzeRefArgument(arg, i, expr)
+		} else {
+			argType, err = s.analyzeExpression(arg)
+			argType = promote(&expr.Arguments[i], argType, methodSymbol.ParamTypes[i], expr.Line)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if argType != methodSymbol.ParamTypes[i] {
+			return "", fmt.Errorf("type mismatch in argument %d of method '%s' at line %d: expected %s, got %s",
+				i+1, expr.Name, expr.Line, methodSymbol.ParamTypes[i], argType)
+		}
+	}
+
+	// to rotl kai to rotr pairnoun plhthos bytes opws oi metatopiseis
+	if methodSymbol.Kind == "builtin" && (expr.Name == "rotl" || expr.Name == "rotr") {
+		if err := s.analyzeShiftCount(expr.Arguments[1], expr.Line); err != nil {
+			return "", err
+		}
+	}
+
+	return methodSymbol.Type, nil
+}
+
+// to struct pernaei me th dieythinsh tou, ara to orisma einai metablhth
+func (s *SemanticAnalyzer) analyzeStructArgument(arg Expression, i int, call *MethodCall) (string, error) {
+	if identifier, ok := arg.(*Identifier); ok {
+		if symbol, exists := s.currentTable.Resolve(identifier.Name); exists && symbol.Struct != nil {
+			return symbol.Type, nil
+		}
+	}
+	return "", fmt.Errorf("argument %d of method '%s' at line %d must be a struct variable",
+		i+1, call.Name, call.Line)
+}
+
+// kathe grammh tou asm einai mia entolh MIX (xwris label), ta %onoma
+// prepei na einai metablhtes, parametroi h stathere pou fainontai edw
+func (s *SemanticAnalyzer) analyzeAsmStatement(stmt *AsmStatement) error {
+	for _, line := range stmt.Lines {
+		fields := strings.Fields(line.Text)
+		op := fields[0]
+		if !mix.IsOperation(op) {
+			return fmt.Errorf("unknown MIX operation '%s' in asm at line %d", op, line.Line)
+		}
+
+		names := asmPlaceholder.FindAllStringSubmatch(line.Text, -1)
+		if len(names) != strings.Count(line.Text, "%") {
+			return fmt.Errorf("expected a name after '%%' in asm at line %d", line.Line)
+		}
+		for _, name := range names {
+			symbol, exists := s.currentTable.Resolve(name[1])
+			if !exists {
+				return fmt.Errorf("undefined identifier '%s' in asm at line %d", name[1], line.Line)
+			}
+			if symbol.Kind == "method" {
+				return fmt.Errorf("method '%s' cannot be used in asm at line %d", name[1], line.Line)
+			}
+		}
+
+		// meta to operand h grammh einai sxolio
+		if len(fields) > 1 {
+			if err := s.analyzeAsmOperand(fields[1], line.Line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// operand ADDRESS[,I][(F)] entolhs asm, wste ta lathh na anaferontai sth grammh
+// tou phgaiou kwdika kai oxi tou assembler. To ADDRESS einai literal h ekfrash
+// apo arithmous, '*' kai %onoma. Oi metablhtes kai oi parametroi ginontai
+// "n,6" kai oi stathere "=n=", ara den mpainoun se ekfrash, kai to "n,6" den
+// pairnei allo index register
+func (s *SemanticAnalyzer) analyzeAsmOperand(operand string, line int) error {
+	address, index, field := asmOperandParts(operand)
+
+	if field != "" && !validAsmField(field) {
+		return fmt.Errorf("invalid field '(%s)' in asm at line %d", field, line)
+	}
+	if index != "" && !asmIndex.MatchString(index) {
+		return fmt.Errorf("invalid index register '%s' in asm at line %d", index, line)
+	}
+	if strings.HasPrefix(address, "=") {
+		if !asmLiteral.MatchString(address) {
+			return fmt.Errorf("invalid literal '%s' in asm at line %d", address, line)
+		}
+		return nil
+	}
+
+	tokens := asmToken.FindAllString(address, -1)
+	expectTerm := true
+	var framed *Symbol // metablhth, parametros h statherh sto ADDRESS
+	for i, token := range tokens {
+		if !expectTerm {
+			if !isAsmOperator(token) {
+				return fmt.Errorf("invalid address '%s' in asm at line %d", address, line)
+			}
+			expectTerm = true
+			continue
+		}
+		if i == 0 && (token == "+" || token == "-") {
+			continue
+		}
+
+		switch {
+		case token == "*" || isDigits(token):
+		case strings.HasPrefix(token, "%"):
+			if symbol, _ := s.currentTable.Resolve(token[1:]); symbol.Kind != "global" {
+				framed = symbol
+			}
+		case asmSymbol.MatchString(token):
+			return fmt.Errorf("undefined symbol '%s' in asm at line %d", token, line)
+		default:
+			return fmt.Errorf("invalid address '%s' in asm at line %d", address, line)
+		}
+		expectTerm = false
+	}
+	if expectTerm && len(tokens) > 0 {
+		return fmt.Errorf("invalid address '%s' in asm at line %d", address, line)
+	}
+
+	if framed != nil && len(tokens) > 1 {
+		return fmt.Errorf("'%%%s' cannot be part of an expression in asm at line %d", framed.Name, line)
+	}
+	if framed != nil && framed.Kind != "const" && index != "" {
+		return fmt.Errorf("'%%%s' is a frame address and cannot take an index register in asm at line %d", framed.Name, line)
+	}
+	return nil
+}
+
+// xwrizei to operand sto ADDRESS, to I kai to F, opws o assembler
+func asmOperandParts(operand string) (string, string, string) {
+	rest, literal := operand, ""
+
+	// to literal mporei na periexei ',' h '('
+	if strings.HasPrefix(rest, "=") {
+		if end := strings.Index(rest[1:], "="); end >= 0 {
+			literal, rest = rest[:end+2], rest[end+2:]
+		}
+	}
+
+	field := ""
+	if open := strings.Index(rest, "("); open >= 0 && strings.HasSuffix(rest, ")") {
+		rest, field = rest[:open], rest[open+1:len(rest)-1]
+	}
+	rest, index, _ := strings.Cut(rest, ",")
+	return literal + rest, index, field
+}
+
+// F ths entolhs: L:R me 0 <= L <= R <= 5 h enas arithmos (p.x. monada I/O)
+func validAsmField(field string) bool {
+	left, right, pair := strings.Cut(field, ":")
+	if !isDigits(left) || (pair && !isDigits(right)) {
+		return false
+	}
+	l, _ := strconv.Atoi(left)
+	if !pair {
+		return l < mix.ByteSize
+	}
+	r, _ := strconv.Atoi(right)
+	return l <= r && r <= mix.WordBytes
+}
+
+func isAsmOperator(token string) bool {
+	return token == "+" || token == "-" || token == "*" || token == "/" || token == "//" || token == ":"
+}
+
+func isDigits(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, ch := range text {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// to orisma mias parametrou ref prepei na exei dieythinsh: metablhth,
+// parametros, stoixeio pinaka h melos struct
+func (s *SemanticAnalyzer) analyzeRefArgument(arg Expression, i int, call *MethodCall) (string, error) {
+	switch a := arg.(type) {
+	case *Identifier:
+		if symbol, exists := s.currentTable.Resolve(a.Name); exists && symbol.Kind == "const" {
+			break
+		}
+		return s.analyzeIdentifier(a)
+	case *IndexExpression, *MemberExpression:
+		return s.analyzeExpression(arg)
+	}
+	return "", fmt.Errorf("argument %d of method '%s' at line %d must be a variable, array element or member for a ref parameter",
+		i+1, call.Name, call.Line)
+}
+
+// klhsh methodou ws entolh, h timh (an yparxei) agnoeitai
+func (s *SemanticAnalyzer) analyzeExpressionStatement(stmt *ExpressionStatement) error {
+	call, ok := stmt.Expression.(*MethodCall)
+	if !ok {
+		return fmt.Errorf("only method calls can be used as statements at line %d", stmt.Line)
+	}
+
+	_, err := s.analyzeMethodCall(call)
+	return err
+}
+
+func (s *SemanticAnalyzer) analyzeReturnStatement(stmt *ReturnStatement) error {
+	// elegxos an to type tou return tairiazei me to method type
+	methodSymbol, exists := s.globalSymbols.Symbols[s.currentMethod]
+	if !exists {
+		return fmt.Errorf("return statement outside of method at line %d", stmt.Line)
+	}
+
+	// return xwris timh mono se void methodo kai to antistrofo
+	if stmt.Expression == nil {
+		if methodSymbol.Type != "void" {
+			return fmt.Errorf("missing return value in method '%s' at line %d", s.currentMethod, stmt.Line)
+		}
+		return nil
+	}
+	if methodSymbol.Type == "void" {
+		return fmt.Errorf("cannot return a value from void method '%s' at line %d", s.currentMethod, stmt.Line)
+	}
+
+	// elegxos return
+	exprType, err := s.analyzeExpression(stmt.Expression)
+	if err != nil {
+		return err
+	}
+	exprType = promote(&stmt.Expression, exprType, methodSymbol.Type, stmt.Line)
+
+	if exprType != methodSymbol.Type {
+		return fmt.Errorf("type mismatch in return statement at line %d: expected %s, got %s",
+			stmt.Line, methodSymbol.Type, exprType)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) analyzeIfStatement(stmt *IfStatement) error {
+	if err := s.analyzeCondition(stmt.Condition, "if", stmt.Line); err != nil {
+		return err
+	}
+
+	// analysh to then
+	if err := s.analyzeStatement(stmt.ThenStmt); err != nil {
+		return err
+	}
+
+	// analysh to else
+	if stmt.ElseStmt != nil {
+		if err := s.analyzeStatement(stmt.ElseStmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SemanticAnalyzer) analyzeWhileStatement(stmt *WhileStatement) error {
+	// elegxos condition
+	if err := s.analyzeCondition(stmt.Condition, "while", stmt.Line); err != nil {
+		return err
 	}
 
 	// ++ sto loop depth gia break validation
 	s.loopDepth++
 
 	// analysh to body
-	err = s.analyzeStatement(stmt.Body)
+	err := s.analyzeStatement(stmt.Body)
 
 	s.loopDepth--
 
 	return err
 }
 
-func (s *SemanticAnalyzer) analyzeBreakStatement(stmt *BreakStatement) error {
+func (s *SemanticAnalyzer) analyzeForStatement(stmt *ForStatement) error {
+	if stmt.Init != nil {
+		if err := s.analyzeStatement(stmt.Init); err != nil {
+			return err
+		}
+	}
+
+	// elegxos condition (an yparxei)
+	if stmt.Condition != nil {
+		if err := s.analyzeCondition(stmt.Condition, "for", stmt.Line); err != nil {
+			return err
+		}
+	}
+
+	if stmt.Step != nil {
+		if err := s.analyzeStatement(stmt.Step); err != nil {
+			return err
+		}
+	}
+
+	s.loopDepth++
+	err := s.analyzeStatement(stmt.Body)
+	s.loopDepth--
+
+	return err
+}
+
+func (s *SemanticAnalyzer) analyzeDoWhileStatement(stmt *DoWhileStatement) error {
+	s.loopDepth++
+	err := s.analyzeStatement(stmt.Body)
+	s.loopDepth--
+	if err != nil {
+		return err
+	}
+
+	return s.analyzeCondition(stmt.Condition, "do-while", stmt.Line)
+}
+
+// oi etiketes twn case prepei na einai stathere int kai monadikes
+func (s *SemanticAnalyzer) analyzeSwitchStatement(stmt *SwitchStatement) error {
+	exprType, err := s.analyzeExpression(stmt.Expression)
+	if err != nil {
+		return err
+	}
+	if exprType != "int" && exprType != "char" {
+		return fmt.Errorf("switch expression at line %d must be int or char, got %s", stmt.Line, exprType)
+	}
+
+	seen := make(map[int]int) // timh -> grammh
+	hasDefault := false
+	for _, switchCase := range stmt.Cases {
+		if switchCase.Value == nil {
+			if hasDefault {
+				return fmt.Errorf("multiple default labels in switch at line %d", switchCase.Line)
+			}
+			hasDefault = true
+			continue
+		}
+
+		caseType, err := s.analyzeExpression(switchCase.Value)
+		if err != nil {
+			return err
+		}
+		value, ok := evaluateConstant(switchCase.Value, s.currentTable)
+		if caseType != exprType || !ok {
+			return fmt.Errorf("case label at line %d must be a constant %s expression", switchCase.Line, exprType)
+		}
+		if line, exists := seen[value]; exists {
+			return fmt.Errorf("duplicate case label %d at line %d (first at line %d)", value, switchCase.Line, line)
+		}
+		seen[value] = switchCase.Line
+	}
+
+	s.switchDepth++
+	defer func() { s.switchDepth-- }()
+	for _, switchCase := range stmt.Cases {
+		for _, caseStmt := range switchCase.Statements {
+			if err := s.analyzeStatement(caseStmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) analyzeContinueStatement(stmt *ContinueStatement) error {
+	if stmt.Label != "" && !s.hasLoopLabel(stmt.Label) {
+		return fmt.Errorf("continue label '%s' at line %d does not name an enclosing loop", stmt.Label, stmt.Line)
+	}
+
 	// elegxos an eimaste se loop
 	if s.loopDepth == 0 {
-		return fmt.Errorf("break statement outside of loop at line %d", stmt.Line)
+		return fmt.Errorf("continue statement outside of loop at line %d", stmt.Line)
+	}
+	return nil
+}
+
+// mono broxgoi pairnoun etiketa, monadikh anamesa stous exwterikous broxgous
+func (s *SemanticAnalyzer) analyzeLabeledStatement(stmt *LabeledStatement) error {
+	switch stmt.Statement.(type) {
+	case *WhileStatement, *ForStatement, *DoWhileStatement:
+	default:
+		return fmt.Errorf("label '%s' at line %d must be followed by a loop", stmt.Label, stmt.Line)
+	}
+
+	if s.hasLoopLabel(stmt.Label) {
+		return fmt.Errorf("label '%s' at line %d is already used by an enclosing loop", stmt.Label, stmt.Line)
+	}
+
+	s.loopLabels = append(s.loopLabels, stmt.Label)
+	err := s.analyzeStatement(stmt.Statement)
+	s.loopLabels = s.loopLabels[:len(s.loopLabels)-1]
+
+	return err
+}
+
+func (s *SemanticAnalyzer) hasLoopLabel(label string) bool {
+	for _, name := range s.loopLabels {
+		if name == label {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SemanticAnalyzer) analyzeBreakStatement(stmt *BreakStatement) error {
+	if stmt.Label != "" && !s.hasLoopLabel(stmt.Label) {
+		return fmt.Errorf("break label '%s' at line %d does not name an enclosing loop", stmt.Label, stmt.Line)
+	}
+
+	// elegxos an eimaste se loop h switch
+	if s.loopDepth == 0 && s.switchDepth == 0 {
+		return fmt.Errorf("break statement outside of loop or switch at line %d", stmt.Line)
 	}
 	return nil
 }
 
 func (s *SemanticAnalyzer) analyzeAssignment(stmt *Assignment) error {
 	// elegxos an einai dlwmenh h metavlhti
-	symbol, exists := s.currentTable.Lookup(stmt.Variable)
+	symbol, exists := s.currentTable.Resolve(stmt.Variable)
 	if !exists {
 		return fmt.Errorf("undefined variable '%s' at line %d", stmt.Variable, stmt.Line)
 	}
@@ -398,16 +1409,340 @@ func (s *SemanticAnalyzer) analyzeAssignment(stmt *Assignment) error {
 		return fmt.Errorf("cannot assign to method '%s' at line %d", stmt.Variable, stmt.Line)
 	}
 
+	// oi stathere den allazoun
+	if symbol.Kind == "const" {
+		return fmt.Errorf("cannot assign to constant '%s' at line %d", stmt.Variable, stmt.Line)
+	}
+
+	// pedio ths lekshs, melos, stoixeio pinaka h olokliros pinakas
+	targetType := symbol.Type
+	if stmt.Field != nil {
+		if err := s.analyzeField(symbol, stmt.Index, stmt.Member, *stmt.Field, stmt.Line); err != nil {
+			return err
+		}
+		targetType = "int"
+	} else if stmt.Member != "" {
+		memberType, err := s.memberType(symbol, stmt.Member, stmt.Line)
+		if err != nil {
+			return err
+		}
+		targetType = memberType
+	} else if stmt.Index != nil {
+		if err := s.analyzeArrayIndex(symbol, stmt.Index, stmt.Line); err != nil {
+			return err
+		}
+		targetType = elementType(symbol.Type)
+	} else if isArrayType(symbol.Type) {
+		return fmt.Errorf("cannot assign to array '%s' at line %d", stmt.Variable, stmt.Line)
+	} else if symbol.Struct != nil {
+		return fmt.Errorf("cannot assign to struct '%s' at line %d", stmt.Variable, stmt.Line)
+	}
+
 	// elegxos tou assigned expression
 	exprType, err := s.analyzeExpression(stmt.Expression)
 	if err != nil {
 		return err
 	}
+	exprType = promote(&stmt.Expression, exprType, targetType, stmt.Line)
 
 	// type compatibility
-	if exprType != symbol.Type {
+	if exprType != targetType {
 		return fmt.Errorf("type mismatch in assignment to '%s' at line %d: expected %s, got %s",
-			symbol.Name, stmt.Line, symbol.Type, exprType)
+			symbol.Name, stmt.Line, targetType, exprType)
 	}
 	return nil
 }
+
+// timh statheris ekfrashs (literals, const kai telestes),
+// false an den einai statheri h den xwraei se mia leksh
+func evaluateConstant(expr Expression, scope *SymbolTable) (int, bool) {
+	switch e := expr.(type) {
+	case *NumberLiteral:
+		value, err := strconv.Atoi(e.Value)
+		return value, err == nil && value <= MAX_LONG
+
+	case *FloatLiteral:
+		return floatConstant(e)
+
+	case *CastExpression:
+		value, ok := evaluateConstant(e.Expression, scope)
+		if !ok {
+			return 0, false
+		}
+		return convertConstant(value, e.FromType, e.Type)
+
+	case *BooleanLiteral:
+		return boolValue(e.Value), true
+
+	case *CharLiteral:
+		return mix.CharCode(rune(e.Value)), true
+
+	case *Identifier:
+		if symbol, exists := scope.Resolve(e.Name); exists && symbol.Kind == "const" {
+			return symbol.Value, true
+		}
+
+	case *FieldExpression:
+		if symbol, exists := scope.Resolve(e.Name); exists && symbol.Kind == "const" && e.Index == nil {
+			word, _ := mix.NewWord(int64(symbol.Value))
+			return int(word.Field(e.Field.Left, e.Field.Right).Value()), true
+		}
+
+	case *UnaryExpression:
+		operand, ok := evaluateConstant(e.Operand, scope)
+		if !ok {
+			return 0, false
+		}
+		switch e.Operator {
+		case "-":
+			return -operand, true
+		case "!":
+			return boolValue(operand == 0), true
+		}
+
+	case *BinaryExpression:
+		left, ok := evaluateConstant(e.Left, scope)
+		if !ok {
+			return 0, false
+		}
+		right, ok := evaluateConstant(e.Right, scope)
+		if !ok {
+			return 0, false
+		}
+		if e.OperandType == "float" {
+			return evaluateFloatConstant(e.Operator, left, right)
+		}
+
+		limit := MAX_WORD
+		if e.OperandType == "long" {
+			limit = MAX_LONG
+		}
+
+		var result int
+		switch e.Operator {
+		case "+":
+			result = left + right
+		case "-":
+			result = left - right
+		case "*":
+			// to ginomeno dyo long mporei na mhn xwraei oute se int64
+			if right != 0 && abs(left) > limit/abs(right) {
+				return 0, false
+			}
+			result = left * right
+		case "<<", ">>":
+			// o arnhtikos arithmos bytes menei gia to runtime error
+			if right < 0 {
+				return 0, false
+			}
+			result = shiftConstant(left, right, e.Operator == "<<")
+		case "/", "%":
+			// h diairesh me 0 menei gia to runtime error
+			if right == 0 {
+				return 0, false
+			}
+			if e.Operator == "/" {
+				result = left / right
+			} else {
+				result = left % right
+			}
+		case "==":
+			result = boolValue(left == right)
+		case "!=":
+			result = boolValue(left != right)
+		case "<":
+			result = boolValue(left < right)
+		case "<=":
+			result = boolValue(left <= right)
+		case ">":
+			result = boolValue(left > right)
+		case ">=":
+			result = boolValue(left >= right)
+		case "&&":
+			result = boolValue(left != 0 && right != 0)
+		case "||":
+			result = boolValue(left != 0 || right != 0)
+		default:
+			return 0, false
+		}
+		return result, result >= -limit && result <= limit
+	}
+	return 0, false
+}
+
+// h leksh float tou literal ws akeraios, false an den xwraei o ekthetis
+func floatConstant(literal *FloatLiteral) (int, bool) {
+	value, err := strconv.ParseFloat(literal.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return floatWord(value)
+}
+
+func floatWord(value float64) (int, bool) {
+	w, overflow := mix.NewFloat(value)
+	return int(w.Value()), !overflow
+}
+
+func floatOf(word int) float64 {
+	w, _ := mix.NewWord(int64(word))
+	return w.Float()
+}
+
+// FLOT kai FIX kata th metaglwttish
+func convertConstant(value int, fromType, toType string) (int, bool) {
+	switch {
+	case fromType == "int" && toType == "float":
+		return floatWord(float64(value))
+	case fromType == "float" && toType == "int":
+		result := math.Round(floatOf(value))
+		return int(result), math.Abs(result) <= MAX_WORD
+	case fromType == "long" && toType == "int":
+		return value, abs(value) <= MAX_WORD
+	}
+	return value, true
+}
+
+// praksh metaksy statherwn float (oi times einai lekseis float)
+func evaluateFloatConstant(op string, left, right int) (int, bool) {
+	a, b := floatOf(left), floatOf(right)
+	switch op {
+	case "+":
+		return floatWord(a + b)
+	case "-":
+		return floatWord(a - b)
+	case "*":
+		return floatWord(a * b)
+	case "/":
+		// h diairesh me 0 menei gia to runtime error
+		if b == 0 {
+			return 0, false
+		}
+		return floatWord(a / b)
+	case "==":
+		return boolValue(a == b), true
+	case "!=":
+		return boolValue(a != b), true
+	case "<":
+		return boolValue(a < b), true
+	case "<=":
+		return boolValue(a <= b), true
+	case ">":
+		return boolValue(a > b), true
+	case ">=":
+		return boolValue(a >= b), true
+	}
+	return 0, false
+}
+
+// o int ginetai float h long (implicit cast) otan o allos typos einai
+// float h long, epistrefei ton typo ths ekfrashs meta to promotion
+func promote(expr *Expression, exprType, targetType string, line int) string {
+	if exprType != "int" || (targetType != "float" && targetType != "long") {
+		return exprType
+	}
+	*expr = &CastExpression{Type: targetType, Expression: *expr, FromType: "int", Line: line}
+	return targetType
+}
+
+func isNumeric(typ string) bool {
+	return typ == "int" || typ == "float" || typ == "long"
+}
+
+// ta literals pou den xwrane se leksh einai long
+func literalType(literal *NumberLiteral) string {
+	if value, err := strconv.Atoi(literal.Value); err == nil && abs(value) > MAX_WORD {
+		return "long"
+	}
+	return "int"
+}
+
+// o long pianei dyo lekseis (rA kai rX), oi alloi typoi mia
+func typeWords(typ string) int {
+	if typ == "long" {
+		return 2
+	}
+	return 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// lekseis pou pianei ena keimeno se pinaka typou elemType
+func stringWords(elemType, text string) int {
+	if elemType == "char" {
+		return len(text)
+	}
+	return (len(text) + mix.WordBytes - 1) / mix.WordBytes
+}
+
+// metatopish kata bytes tou MIX (6 bits), opws ta SLA/SRA: to proshmo
+// menei kai ta bytes pou vgainoun apo th leksh xanontai
+func shiftConstant(value, count int, left bool) int {
+	magnitude := abs(value)
+	switch {
+	case count >= mix.WordBytes:
+		magnitude = 0
+	case left:
+		magnitude = (magnitude << (6 * count)) & MAX_WORD
+	default:
+		magnitude >>= 6 * count
+	}
+
+	if value < 0 {
+		return -magnitude
+	}
+	return magnitude
+}
+
+func isShift(op string) bool {
+	return op == "<<" || op == ">>"
+}
+
+func isRelational(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">=" || op == "==" || op == "!="
+}
+
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// typos pinaka p.x. int[]
+func arrayType(elemType string) string {
+	return elemType + "[]"
+}
+
+// %onoma mesa se asm
+var asmPlaceholder = regexp.MustCompile(`%([A-Za-z][A-Za-z0-9_]*)`)
+
+// kommatia tou operand mias entolhs asm
+var (
+	asmToken   = regexp.MustCompile(`%[A-Za-z][A-Za-z0-9_]*|[A-Za-z0-9_]+|//|.`)
+	asmSymbol  = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	asmIndex   = regexp.MustCompile(`^[0-6]$`)
+	asmLiteral = regexp.MustCompile(`^=[-+]?[0-9]+(\([0-9]+(:[0-9]+)?\))?=$`)
+)
+
+// o typos struct grafetai "struct onoma"
+func structTypeName(name string) string {
+	return "struct " + name
+}
+
+func isStructType(typ string) bool {
+	return strings.HasPrefix(typ, "struct ")
+}
+
+func isArrayType(typ string) bool {
+	return strings.HasSuffix(typ, "[]")
+}
+
+func elementType(typ string) string {
+	return strings.TrimSuffix(typ, "[]")
+}
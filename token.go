@@ -8,16 +8,34 @@ const (
 	//literals
 	TOK_ID TokenType = iota
 	TOK_NUM
+	TOK_FLOATNUM // 3.14
 	TOK_TRUE
 	TOK_FALSE
+	TOK_CHARLIT // 'A'
+	TOK_STRING  // "HELLO"
 
 	// deysmemenes lekseis
 	TOK_INT
+	TOK_BOOL
+	TOK_CHAR
+	TOK_FLOAT
+	TOK_LONG
+	TOK_STRUCT
+	TOK_REF
 	TOK_RETURN
 	TOK_IF
 	TOK_ELSE
 	TOK_WHILE
 	TOK_BREAK
+	TOK_CONST
+	TOK_VOID
+	TOK_FOR
+	TOK_DO
+	TOK_CONTINUE
+	TOK_SWITCH
+	TOK_CASE
+	TOK_DEFAULT
+	TOK_ASM // asm { ... }, h timh einai to keimeno tou block
 
 	// operatos
 	TOK_ASSIGN   // =
@@ -25,6 +43,9 @@ const (
 	TOK_MINUS    // -
 	TOK_MULTIPLY // *
 	TOK_DIVIDE   // /
+	TOK_MODULO   // %
+	TOK_SHL      // <<
+	TOK_SHR      // >>
 
 	// relational ops
 	TOK_LT // <
@@ -34,12 +55,21 @@ const (
 	TOK_EQ // ==
 	TOK_NE // !=
 
+	// logical ops
+	TOK_AND // &&
+	TOK_OR  // ||
+	TOK_NOT // !
+
 	//diaxwristika
 	TOK_LPAREN    // (
 	TOK_RPAREN    // )
 	TOK_LBRACE    // {
 	TOK_RBRACE    // }
+	TOK_LBRACKET  // [
+	TOK_RBRACKET  // ]
 	TOK_COMMA     // ,
+	TOK_COLON     // :
+	TOK_DOT       // .
 	TOK_SEMICOLON // ;
 
 	TOK_EOF   // EOF
@@ -62,42 +92,85 @@ func (t Token) String() string {
 var tokenTypeNames = map[TokenType]string{
 	TOK_ID:        "ID",
 	TOK_NUM:       "NUM",
+	TOK_FLOATNUM:  "FLOATNUM",
 	TOK_TRUE:      "TRUE",
 	TOK_FALSE:     "FALSE",
+	TOK_CHARLIT:   "CHARLIT",
+	TOK_STRING:    "STRING",
 	TOK_INT:       "INT",
+	TOK_BOOL:      "BOOL",
+	TOK_CHAR:      "CHAR",
+	TOK_FLOAT:     "FLOAT",
+	TOK_LONG:      "LONG",
+	TOK_STRUCT:    "STRUCT",
+	TOK_REF:       "REF",
 	TOK_RETURN:    "RETURN",
 	TOK_IF:        "IF",
 	TOK_ELSE:      "ELSE",
 	TOK_WHILE:     "WHILE",
 	TOK_BREAK:     "BREAK",
+	TOK_CONST:     "CONST",
+	TOK_VOID:      "VOID",
+	TOK_FOR:       "FOR",
+	TOK_DO:        "DO",
+	TOK_CONTINUE:  "CONTINUE",
+	TOK_SWITCH:    "SWITCH",
+	TOK_CASE:      "CASE",
+	TOK_DEFAULT:   "DEFAULT",
+	TOK_ASM:       "ASM",
 	TOK_ASSIGN:    "ASSIGN",
 	TOK_PLUS:      "PLUS",
 	TOK_MINUS:     "MINUS",
 	TOK_MULTIPLY:  "MULTIPLY",
 	TOK_DIVIDE:    "DIVIDE",
+	TOK_MODULO:    "MODULO",
+	TOK_SHL:       "SHL",
+	TOK_SHR:       "SHR",
 	TOK_LT:        "LT",
 	TOK_LE:        "LE",
 	TOK_GT:        "GT",
 	TOK_GE:        "GE",
 	TOK_EQ:        "EQ",
 	TOK_NE:        "NE",
+	TOK_AND:       "AND",
+	TOK_OR:        "OR",
+	TOK_NOT:       "NOT",
 	TOK_LPAREN:    "LPAREN",
 	TOK_RPAREN:    "RPAREN",
 	TOK_LBRACE:    "LBRACE",
 	TOK_RBRACE:    "RBRACE",
+	TOK_LBRACKET:  "LBRACKET",
+	TOK_RBRACKET:  "RBRACKET",
 	TOK_COMMA:     "COMMA",
+	TOK_COLON:     "COLON",
+	TOK_DOT:       "DOT",
 	TOK_SEMICOLON: "SEMICOLON",
 	TOK_EOF:       "EOF",
 	TOK_ERROR:     "ERROR",
 }
 
 var keywords = map[string]TokenType{
-	"int":    TOK_INT,
-	"return": TOK_RETURN,
-	"if":     TOK_IF,
-	"else":   TOK_ELSE,
-	"while":  TOK_WHILE,
-	"break":  TOK_BREAK,
-	"true":   TOK_TRUE,
-	"false":  TOK_FALSE,
+	"int":      TOK_INT,
+	"bool":     TOK_BOOL,
+	"char":     TOK_CHAR,
+	"float":    TOK_FLOAT,
+	"long":     TOK_LONG,
+	"struct":   TOK_STRUCT,
+	"ref":      TOK_REF,
+	"return":   TOK_RETURN,
+	"if":       TOK_IF,
+	"else":     TOK_ELSE,
+	"while":    TOK_WHILE,
+	"break":    TOK_BREAK,
+	"const":    TOK_CONST,
+	"void":     TOK_VOID,
+	"for":      TOK_FOR,
+	"do":       TOK_DO,
+	"continue": TOK_CONTINUE,
+	"switch":   TOK_SWITCH,
+	"case":     TOK_CASE,
+	"default":  TOK_DEFAULT,
+	"asm":      TOK_ASM,
+	"true":     TOK_TRUE,
+	"false":    TOK_FALSE,
 }